@@ -2,25 +2,46 @@ package config
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 )
 
 var envMap = map[string]string{
-	port:             defaultPort,
-	logLevel:         defaultlogLevel,
-	gearDatabase:     defaultGearDatabase,
-	armorCollection:  defaultArmorCollection,
-	weaponCollection: defaultWeaponCollection,
+	port:                 defaultPort,
+	logLevel:             defaultlogLevel,
+	gearDatabase:         defaultGearDatabase,
+	armorCollection:      defaultArmorCollection,
+	weaponCollection:     defaultWeaponCollection,
+	attachmentCollection: defaultAttachmentCollection,
+	gearCollection:       defaultGearCollection,
+	vehicleCollection:    defaultVehicleCollection,
+	inventoryCollection:  defaultInventoryCollection,
+	sourceRegistry:       defaultSourceRegistry,
+	templateCollection:   defaultTemplateCollection,
+	shopCollection:       defaultShopCollection,
+	lootCollection:       defaultLootCollection,
+	instanceCollection:   defaultInstanceCollection,
+	maxPageSize:          defaultMaxPageSize,
 }
 
 //Config is the general struct for app configuration
 type Config struct {
-	Port             string       `json:"port"`
-	GearDatabase     string       `json:"characterDatabase"`
-	ArmorCollection  string       `json:"characterCollection"`
-	WeaponCollection string       `json:"characterArchive"`
-	LogLevel         logrus.Level `json:"log-level"`
+	Port                 string       `json:"port"`
+	GearDatabase         string       `json:"gearDatabase"`
+	ArmorCollection      string       `json:"armorCollection"`
+	WeaponCollection     string       `json:"weaponCollection"`
+	AttachmentCollection string       `json:"attachmentCollection"`
+	GearCollection       string       `json:"gearCollection"`
+	VehicleCollection    string       `json:"vehicleCollection"`
+	InventoryCollection  string       `json:"inventoryCollection"`
+	SourceRegistry       string       `json:"sourceRegistry"`
+	TemplateCollection   string       `json:"templateCollection"`
+	ShopCollection       string       `json:"shopCollection"`
+	LootCollection       string       `json:"lootCollection"`
+	InstanceCollection   string       `json:"instanceCollection"`
+	MaxPageSize          int          `json:"maxPageSize"`
+	LogLevel             logrus.Level `json:"logLevel"`
 }
 
 //Accessor is the interface setup for any configuration accessor
@@ -42,12 +63,28 @@ func New(accessor Accessor) (c *Config, err error) {
 		logrus.Warnf("Cannot load log-level: %v", err)
 	}
 
+	pageSize, err := strconv.Atoi(envMap[maxPageSize])
+	if err != nil || pageSize < 1 {
+		logrus.Warnf("Cannot load max page size %v, using %v", envMap[maxPageSize], defaultMaxPageSize)
+		pageSize, _ = strconv.Atoi(defaultMaxPageSize)
+	}
+
 	config := Config{
-		Port:             envMap[port],
-		GearDatabase:     envMap[gearDatabase],
-		ArmorCollection:  envMap[armorCollection],
-		WeaponCollection: envMap[weaponCollection],
-		LogLevel:         currentLogLevel,
+		Port:                 envMap[port],
+		GearDatabase:         envMap[gearDatabase],
+		ArmorCollection:      envMap[armorCollection],
+		WeaponCollection:     envMap[weaponCollection],
+		AttachmentCollection: envMap[attachmentCollection],
+		GearCollection:       envMap[gearCollection],
+		VehicleCollection:    envMap[vehicleCollection],
+		InventoryCollection:  envMap[inventoryCollection],
+		SourceRegistry:       envMap[sourceRegistry],
+		TemplateCollection:   envMap[templateCollection],
+		ShopCollection:       envMap[shopCollection],
+		LootCollection:       envMap[lootCollection],
+		InstanceCollection:   envMap[instanceCollection],
+		MaxPageSize:          pageSize,
+		LogLevel:             currentLogLevel,
 	}
 	return &config, nil
 }
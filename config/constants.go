@@ -1,17 +1,37 @@
 package config
 
 const (
-	port             = "PORT"
-	logLevel         = "LOG_LEVEL"
-	gearDatabase     = "GEAR_DATABASE"
-	armorCollection  = "ARMOR_COLLECTION"
-	weaponCollection = "WEAPON_COLLECTION"
+	port                 = "PORT"
+	logLevel             = "LOG_LEVEL"
+	gearDatabase         = "GEAR_DATABASE"
+	armorCollection      = "ARMOR_COLLECTION"
+	weaponCollection     = "WEAPON_COLLECTION"
+	attachmentCollection = "ATTACHMENT_COLLECTION"
+	gearCollection       = "GEAR_COLLECTION"
+	vehicleCollection    = "VEHICLE_COLLECTION"
+	inventoryCollection  = "INVENTORY_COLLECTION"
+	sourceRegistry       = "SOURCE_REGISTRY"
+	templateCollection   = "TEMPLATE_COLLECTION"
+	shopCollection       = "SHOP_COLLECTION"
+	lootCollection       = "LOOT_COLLECTION"
+	instanceCollection   = "INSTANCE_COLLECTION"
+	maxPageSize          = "MAX_PAGE_SIZE"
 )
 
 const (
-	defaultPort             = "3000"
-	defaultlogLevel         = "trace"
-	defaultGearDatabase     = "gear"
-	defaultArmorCollection  = "armor"
-	defaultWeaponCollection = "weapons"
+	defaultPort                 = "3000"
+	defaultlogLevel             = "trace"
+	defaultGearDatabase         = "gear"
+	defaultArmorCollection      = "armor"
+	defaultWeaponCollection     = "weapons"
+	defaultAttachmentCollection = "attachments"
+	defaultGearCollection       = "gear"
+	defaultVehicleCollection    = "vehicles"
+	defaultInventoryCollection  = "inventories"
+	defaultSourceRegistry       = ""
+	defaultTemplateCollection   = "templates"
+	defaultShopCollection       = "shops"
+	defaultLootCollection       = "loot"
+	defaultInstanceCollection   = "instances"
+	defaultMaxPageSize          = "100"
 )
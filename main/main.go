@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/geeksheik9/gear-CRUD/config"
+	model "github.com/geeksheik9/gear-CRUD/models"
 	"github.com/geeksheik9/gear-CRUD/pkg/db"
 	"github.com/geeksheik9/gear-CRUD/pkg/handler"
 	"github.com/gorilla/mux"
@@ -27,6 +28,13 @@ func main() {
 		logrus.Fatalf("ERROR LOADING CONFIG: %v", err.Error())
 	}
 
+	if config.SourceRegistry != "" {
+		err = model.LoadSourceRegistry(config.SourceRegistry)
+		if err != nil {
+			logrus.Fatalf("ERROR LOADING SOURCE REGISTRY: %v", err.Error())
+		}
+	}
+
 	timeout := time.Second * 5
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -49,9 +57,26 @@ func main() {
 		logrus.Fatalf("Error no database from client %v", client)
 	}
 
+	indexCtx, cancelIndexes := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancelIndexes()
+	err = database.CreateTextIndexes(indexCtx)
+	if err != nil {
+		logrus.Errorf("Failed to create search indexes: %v", err)
+	}
+
+	go func() {
+		migrated, err := database.MigrateWeaponDamage(context.Background())
+		if err != nil {
+			logrus.Errorf("Failed to migrate weapon damage: %v", err)
+			return
+		}
+		logrus.Infof("Migrated damage on %v weapons", migrated)
+	}()
+
 	gearService := handler.GearService{
-		Version:  version,
-		Database: database,
+		Version:     version,
+		Database:    database,
+		MaxPageSize: config.MaxPageSize,
 	}
 
 	r := mux.NewRouter().StrictSlash(true)
@@ -4,12 +4,41 @@ import "go.mongodb.org/mongo-driver/bson/primitive"
 
 // Armor struct is used to create an Armor object
 type Armor struct {
-	ID          primitive.ObjectID `json:"_id" bson:"_id"`
-	ArmorType   string             `json:"type" bson:"type"`
-	Defense     int64              `json:"defense" bson:"defense"`
-	Soak        int64              `json:"soak" bson:"soak"`
-	Price       int64              `json:"price" bson:"price"`
-	Encumbrance int64              `json:"encumbrance" bson:"encumbrance"`
-	HardPoints  int64              `json:"hardPoints" bson:"hardPoints"`
-	Rarity      int64              `json:"rarity" bson:"rarity"`
+	ID          primitive.ObjectID    `json:"_id" bson:"_id"`
+	ArmorType   string                `json:"type" bson:"type"`
+	Defense     int64                 `json:"defense" bson:"defense"`
+	Soak        int64                 `json:"soak" bson:"soak"`
+	Price       int64                 `json:"price" bson:"price"`
+	Encumbrance int64                 `json:"encumbrance" bson:"encumbrance"`
+	HardPoints  int64                 `json:"hardPoints" bson:"hardPoints"`
+	Rarity      int64                 `json:"rarity" bson:"rarity"`
+	Restricted  bool                  `json:"restricted" bson:"restricted"`
+	Special     string                `json:"special" bson:"special,omitempty"`
+	Qualities   []Quality             `json:"qualities" bson:"qualities"`
+	Attachments []InstalledAttachment `json:"attachments" bson:"attachments,omitempty"`
+	Sources     []SourceReference     `json:"sources,omitempty" bson:"sources,omitempty"`
+	GameLine    GameLine              `json:"gameLine,omitempty" bson:"gameLine,omitempty"`
+}
+
+// NormalizeQualities keeps Special and Qualities in step, documents written before qualities existed are parsed from Special
+func (a *Armor) NormalizeQualities() error {
+	return normalizeQualities(&a.Special, &a.Qualities)
+}
+
+// Validate checks the armor before it is written to the database
+func (a *Armor) Validate() error {
+	validationErr := ValidationError{}
+
+	validationErr.Merge("special", a.NormalizeQualities())
+	validateRarity(a.Rarity, &validationErr)
+	validateHardPoints(a.HardPoints, a.Attachments, &validationErr)
+	validateSources(a.Sources, &a.GameLine, &validationErr)
+
+	return validationErr.OrNil()
+}
+
+// UnmarshalJSON accepts the printed rarity notation, "7 (R)" also marks the armor as restricted
+func (a *Armor) UnmarshalJSON(data []byte) error {
+	type armor Armor
+	return decodeRarityJSON(data, (*armor)(a), &a.Rarity, &a.Restricted)
 }
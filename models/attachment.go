@@ -0,0 +1,151 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ItemKind is the kind of catalog item a document refers to
+type ItemKind string
+
+// The catalog item kinds
+const (
+	WeaponKind ItemKind = "weapon"
+	ArmorKind  ItemKind = "armor"
+	GearKind   ItemKind = "gear"
+)
+
+// ItemKinds lists every kind of catalog item
+var ItemKinds = []ItemKind{WeaponKind, ArmorKind, GearKind}
+
+// AttachableKinds lists every item kind an attachment can be installed on
+var AttachableKinds = []ItemKind{WeaponKind, ArmorKind}
+
+// ParseItemKind returns the item kind matching the given name, ignoring case
+func ParseItemKind(name string) (ItemKind, error) {
+	return parseKind(name, ItemKinds)
+}
+
+func parseKind(name string, kinds []ItemKind) (ItemKind, error) {
+	for _, kind := range kinds {
+		if strings.EqualFold(strings.TrimSpace(name), string(kind)) {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not an item kind, expected one of %v", name, kinds)
+}
+
+// Modifier is a change to an item's stats made by an attachment or one of its modifications
+type Modifier struct {
+	Description string    `json:"description" bson:"description"`
+	Damage      int64     `json:"damage,omitempty" bson:"damage,omitempty"`
+	Critical    int64     `json:"critical,omitempty" bson:"critical,omitempty"`
+	Soak        int64     `json:"soak,omitempty" bson:"soak,omitempty"`
+	Defense     int64     `json:"defense,omitempty" bson:"defense,omitempty"`
+	Encumbrance int64     `json:"encumbrance,omitempty" bson:"encumbrance,omitempty"`
+	Qualities   []Quality `json:"qualities,omitempty" bson:"qualities,omitempty"`
+}
+
+// ModificationOption is a modification that can be completed on an installed attachment up to Count times
+type ModificationOption struct {
+	Modifier `bson:",inline"`
+	Count    int64 `json:"count" bson:"count"`
+}
+
+// Attachment struct is used to create an Attachment object
+type Attachment struct {
+	ID                  primitive.ObjectID   `json:"_id" bson:"_id"`
+	Name                string               `json:"name" bson:"name"`
+	HardPoints          int64                `json:"hardPoints" bson:"hardPoints"`
+	Price               int64                `json:"price" bson:"price"`
+	Rarity              int64                `json:"rarity" bson:"rarity"`
+	Restricted          bool                 `json:"restricted" bson:"restricted"`
+	BaseModifier        Modifier             `json:"baseModifier" bson:"baseModifier"`
+	ModificationOptions []ModificationOption `json:"modificationOptions" bson:"modificationOptions"`
+	AllowedKinds        []ItemKind           `json:"allowedKinds" bson:"allowedKinds"`
+}
+
+// InstalledAttachment is an attachment installed on a weapon or armor
+type InstalledAttachment struct {
+	AttachmentID primitive.ObjectID `json:"attachmentId" bson:"attachmentId"`
+	Name         string             `json:"name" bson:"name"`
+	HardPoints   int64              `json:"hardPoints" bson:"hardPoints"`
+}
+
+// Allows reports whether the attachment can be installed on the given item kind
+func (a *Attachment) Allows(kind ItemKind) bool {
+	for _, allowed := range a.AllowedKinds {
+		if allowed == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Installed returns the record kept on an item when this attachment is installed
+func (a *Attachment) Installed() InstalledAttachment {
+	return InstalledAttachment{
+		AttachmentID: a.ID,
+		Name:         a.Name,
+		HardPoints:   a.HardPoints,
+	}
+}
+
+// Validate checks the attachment before it is written to the database
+func (a *Attachment) Validate() error {
+	validationErr := ValidationError{}
+
+	if strings.TrimSpace(a.Name) == "" {
+		validationErr.Add("name", "is required")
+	}
+	if a.HardPoints < 0 {
+		validationErr.Add("hardPoints", "must not be negative")
+	}
+	validateRarity(a.Rarity, &validationErr)
+
+	if len(a.AllowedKinds) == 0 {
+		validationErr.Add("allowedKinds", "at least one item kind is required")
+	}
+	for i, kind := range a.AllowedKinds {
+		parsed, err := parseKind(string(kind), AttachableKinds)
+		if err != nil {
+			validationErr.Add(fmt.Sprintf("allowedKinds[%d]", i), err.Error())
+			continue
+		}
+		a.AllowedKinds[i] = parsed
+	}
+
+	validationErr.Merge("baseModifier", prefixFields("baseModifier", ValidateQualities(a.BaseModifier.Qualities)))
+	for i := range a.ModificationOptions {
+		field := fmt.Sprintf("modificationOptions[%d]", i)
+		if a.ModificationOptions[i].Count < 1 {
+			validationErr.Add(field+".count", "must be at least 1")
+		}
+		validationErr.Merge(field, prefixFields(field, ValidateQualities(a.ModificationOptions[i].Qualities)))
+	}
+
+	return validationErr.OrNil()
+}
+
+// UsedHardPoints returns the hard points spent on the installed attachments
+func UsedHardPoints(attachments []InstalledAttachment) int64 {
+	var used int64
+	for _, attachment := range attachments {
+		used += attachment.HardPoints
+	}
+	return used
+}
+
+// validateHardPoints checks the installed attachments fit within the item's hard points
+func validateHardPoints(hardPoints int64, attachments []InstalledAttachment, validationErr *ValidationError) {
+	if used := UsedHardPoints(attachments); used > hardPoints {
+		validationErr.Add("attachments", fmt.Sprintf("installed attachments use %v hard points but only %v are available", used, hardPoints))
+	}
+}
+
+// InstallAttachmentRequest is the payload used to install an attachment on a weapon or armor
+type InstallAttachmentRequest struct {
+	AttachmentID primitive.ObjectID `json:"attachmentId"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestAttachment_Validate(t *testing.T) {
+	attachment := Attachment{
+		Name:         "Superior Weapon Customization",
+		HardPoints:   1,
+		AllowedKinds: []ItemKind{"Weapon"},
+		ModificationOptions: []ModificationOption{
+			{Modifier: Modifier{Qualities: []Quality{{Name: "superior"}}}, Count: 1},
+		},
+	}
+
+	err := attachment.Validate()
+	if err != nil || attachment.AllowedKinds[0] != WeaponKind || attachment.ModificationOptions[0].Qualities[0].Name != "Superior" {
+		t.Errorf("Validate() error:\ngot: %+v, %v\nexpected canonical kinds and qualities", attachment, err)
+	}
+	if !attachment.Allows(WeaponKind) || attachment.Allows(ArmorKind) {
+		t.Errorf("Allows() error:\ngot: %v\nexpected: weapons only", attachment.AllowedKinds)
+	}
+}
+
+func TestAttachment_Validate_NestedFields(t *testing.T) {
+	attachment := Attachment{
+		Name:                "Bad Mod",
+		AllowedKinds:        []ItemKind{ArmorKind},
+		ModificationOptions: []ModificationOption{{Modifier: Modifier{Qualities: []Quality{{Name: "Sparkly"}}}}},
+	}
+
+	err := attachment.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 2 || validationErr[1].Field != "modificationOptions[0].qualities[0]" {
+		t.Errorf("Validate() error:\ngot: %v\nexpected: count and nested quality errors", err)
+	}
+}
+
+func TestWeapon_Validate_HardPoints(t *testing.T) {
+	weapon := Weapon{HP: 1, Attachments: []InstalledAttachment{{Name: "Bipod", HardPoints: 1}, {Name: "Sight", HardPoints: 1}}}
+	err := weapon.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 1 || validationErr[0].Field != "attachments" {
+		t.Errorf("Validate() error:\ngot: %v\nexpected: attachments field error", err)
+	}
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The rarity adjustments from the core rulebook
+const (
+	RestrictedRarityModifier  int64 = 1
+	BlackMarketRarityModifier int64 = -1
+)
+
+// Location is a region of the galaxy used to adjust rarity
+type Location string
+
+// The locations on the rarity modifier table
+const (
+	CoreWorlds Location = "Core Worlds"
+	Colonies   Location = "Colonies"
+	OuterRim   Location = "Outer Rim"
+	WildSpace  Location = "Wild Space"
+)
+
+// LocationModifiers maps every location to its rarity modifier, goods are scarcer further from the Core
+var LocationModifiers = map[Location]int64{
+	CoreWorlds: -1,
+	Colonies:   0,
+	OuterRim:   1,
+	WildSpace:  2,
+}
+
+// ParseLocationModifier returns the rarity modifier of a location name, ignoring case and dashes, or of a raw number
+func ParseLocationModifier(location string) (Location, int64, error) {
+	trimmed := strings.TrimSpace(location)
+	if trimmed == "" {
+		return "", 0, nil
+	}
+
+	if modifier, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+		return "", modifier, nil
+	}
+
+	normalized := strings.ReplaceAll(trimmed, "-", " ")
+	for name, modifier := range LocationModifiers {
+		if strings.EqualFold(normalized, string(name)) {
+			return name, modifier, nil
+		}
+	}
+
+	return "", 0, fmt.Errorf("%v is not a location or a number, expected one of %v, %v, %v or %v", location, CoreWorlds, Colonies, OuterRim, WildSpace)
+}
+
+// RarityDifficulty returns the Negotiation check difficulty for finding an item of the given rarity
+func RarityDifficulty(rarity int64) Difficulty {
+	switch {
+	case rarity <= 1:
+		return Simple
+	case rarity <= 3:
+		return Easy
+	case rarity <= 5:
+		return Average
+	case rarity <= 7:
+		return Hard
+	case rarity <= 9:
+		return Daunting
+	default:
+		return Formidable
+	}
+}
+
+// AvailabilityRequest describes where a character is trying to buy an item
+type AvailabilityRequest struct {
+	Location         Location
+	LocationModifier int64
+	BlackMarket      bool
+}
+
+// Availability is the result of a rarity check for purchasing an item
+type Availability struct {
+	ItemID           string   `json:"itemId"`
+	Name             string   `json:"name"`
+	Rarity           int64    `json:"rarity"`
+	Restricted       bool     `json:"restricted"`
+	Location         Location `json:"location,omitempty"`
+	LocationModifier int64    `json:"locationModifier"`
+	BlackMarket      bool     `json:"blackMarket"`
+	AdjustedRarity   int64    `json:"adjustedRarity"`
+	Difficulty       string   `json:"difficulty"`
+	DifficultyDice   int64    `json:"difficultyDice"`
+}
+
+// Check adjusts the rarity of an item for the request and looks up the Negotiation difficulty.
+// Restricted items are harder to find on the open market, while the black market trades in them freely
+// and makes them slightly easier to find. The adjusted rarity stays on the printed 0 to 10 scale.
+func (a AvailabilityRequest) Check(rarity int64, restricted bool) Availability {
+	adjusted := rarity + a.LocationModifier
+	if restricted {
+		if a.BlackMarket {
+			adjusted += BlackMarketRarityModifier
+		} else {
+			adjusted += RestrictedRarityModifier
+		}
+	}
+
+	if adjusted < 0 {
+		adjusted = 0
+	}
+	if adjusted > MaxRarity {
+		adjusted = MaxRarity
+	}
+
+	difficulty := RarityDifficulty(adjusted)
+	return Availability{
+		Rarity:           rarity,
+		Restricted:       restricted,
+		Location:         a.Location,
+		LocationModifier: a.LocationModifier,
+		BlackMarket:      a.BlackMarket,
+		AdjustedRarity:   adjusted,
+		Difficulty:       difficulty.String(),
+		DifficultyDice:   difficulty.Dice(),
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestParseLocationModifier(t *testing.T) {
+	tests := []struct {
+		location string
+		name     Location
+		modifier int64
+		err      bool
+	}{
+		{"", "", 0, false},
+		{"core worlds", CoreWorlds, -1, false},
+		{"Outer-Rim", OuterRim, 1, false},
+		{"WILD SPACE", WildSpace, 2, false},
+		{"-2", "", -2, false},
+		{"Kessel", "", 0, true},
+	}
+
+	for _, test := range tests {
+		name, modifier, err := ParseLocationModifier(test.location)
+		if name != test.name || modifier != test.modifier || (err != nil) != test.err {
+			t.Errorf("ParseLocationModifier(%q) error:\ngot: %v, %v, %v\nexpected: %v, %v, error %v", test.location, name, modifier, err, test.name, test.modifier, test.err)
+		}
+	}
+}
+
+func TestRarityDifficulty(t *testing.T) {
+	expected := []Difficulty{Simple, Simple, Easy, Easy, Average, Average, Hard, Hard, Daunting, Daunting, Formidable}
+	for rarity, difficulty := range expected {
+		if got := RarityDifficulty(int64(rarity)); got != difficulty {
+			t.Errorf("RarityDifficulty(%v) error:\ngot: %v\nexpected: %v", rarity, got, difficulty)
+		}
+	}
+}
+
+func TestAvailabilityRequest_Check(t *testing.T) {
+	tests := []struct {
+		request    AvailabilityRequest
+		rarity     int64
+		restricted bool
+		adjusted   int64
+		difficulty string
+	}{
+		{AvailabilityRequest{Location: CoreWorlds, LocationModifier: -1}, 4, false, 3, "Easy"},
+		{AvailabilityRequest{Location: WildSpace, LocationModifier: 2}, 6, true, 9, "Daunting"},
+		{AvailabilityRequest{Location: WildSpace, LocationModifier: 2, BlackMarket: true}, 6, true, 7, "Hard"},
+		{AvailabilityRequest{LocationModifier: 3}, 9, true, 10, "Formidable"},
+		{AvailabilityRequest{LocationModifier: -3}, 1, false, 0, "Simple"},
+	}
+
+	for _, test := range tests {
+		availability := test.request.Check(test.rarity, test.restricted)
+		if availability.AdjustedRarity != test.adjusted || availability.Difficulty != test.difficulty {
+			t.Errorf("Check() error:\ngot: %+v\nexpected: rarity %v, %v", availability, test.adjusted, test.difficulty)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CraftingModification is an improvement bought with advantages on a crafting check
+type CraftingModification string
+
+// The crafting modifications
+const (
+	DamageModification      CraftingModification = "damage"
+	CriticalModification    CraftingModification = "critical"
+	AccurateModification    CraftingModification = "accurate"
+	SoakModification        CraftingModification = "soak"
+	DefenseModification     CraftingModification = "defense"
+	EncumbranceModification CraftingModification = "encumbrance"
+	HardPointsModification  CraftingModification = "hardPoints"
+)
+
+// CraftingModifications holds the advantage cost of every modification available to each kind of item
+var CraftingModifications = map[ItemKind]map[CraftingModification]int64{
+	WeaponKind: {
+		DamageModification:      3,
+		CriticalModification:    2,
+		AccurateModification:    2,
+		EncumbranceModification: 2,
+		HardPointsModification:  3,
+	},
+	ArmorKind: {
+		SoakModification:        3,
+		DefenseModification:     3,
+		EncumbranceModification: 2,
+		HardPointsModification:  3,
+	},
+}
+
+// TriumphAdvantages is the number of advantages a triumph is worth when paying for modifications
+const TriumphAdvantages = 3
+
+// CraftingTemplate is a Special Modifications template describing the frame or chassis an item is built from
+// and the stats of the resulting item
+type CraftingTemplate struct {
+	ID            primitive.ObjectID `json:"_id" bson:"_id"`
+	Name          string             `json:"name" bson:"name"`
+	Kind          ItemKind           `json:"kind" bson:"kind"`
+	Frame         string             `json:"frame" bson:"frame"`
+	MaterialPrice int64              `json:"materialPrice" bson:"materialPrice"`
+	Rarity        int64              `json:"rarity" bson:"rarity"`
+	Restricted    bool               `json:"restricted" bson:"restricted"`
+	Difficulty    int64              `json:"difficulty" bson:"difficulty"`
+	TimeHours     int64              `json:"timeHours" bson:"timeHours"`
+	Weapon        *Weapon            `json:"weapon,omitempty" bson:"weapon,omitempty"`
+	Armor         *Armor             `json:"armor,omitempty" bson:"armor,omitempty"`
+}
+
+// Validate checks the template before it is written to the database, the result stats must match the kind
+func (t *CraftingTemplate) Validate() error {
+	validationErr := ValidationError{}
+
+	if strings.TrimSpace(t.Name) == "" {
+		validationErr.Add("name", "is required")
+	}
+	if t.MaterialPrice < 0 {
+		validationErr.Add("materialPrice", "must not be negative")
+	}
+	validateRarity(t.Rarity, &validationErr)
+	if t.Difficulty < int64(Simple) || t.Difficulty > int64(Formidable) {
+		validationErr.Add("difficulty", fmt.Sprintf("must be between %v and %v", int64(Simple), int64(Formidable)))
+	}
+	if t.TimeHours < 0 {
+		validationErr.Add("timeHours", "must not be negative")
+	}
+
+	kind, err := ParseItemKind(string(t.Kind))
+	if err != nil || kind == GearKind {
+		validationErr.Add("kind", fmt.Sprintf("%v is not a craftable kind, expected one of %v", t.Kind, AttachableKinds))
+		return validationErr.OrNil()
+	}
+	t.Kind = kind
+
+	switch kind {
+	case WeaponKind:
+		if t.Weapon == nil {
+			validationErr.Add("weapon", "is required for weapon templates")
+			break
+		}
+		t.Armor = nil
+		validationErr.Merge("weapon", prefixFields("weapon", t.Weapon.Validate()))
+	case ArmorKind:
+		if t.Armor == nil {
+			validationErr.Add("armor", "is required for armor templates")
+			break
+		}
+		t.Weapon = nil
+		validationErr.Merge("armor", prefixFields("armor", t.Armor.Validate()))
+	}
+
+	return validationErr.OrNil()
+}
+
+// UnmarshalJSON accepts the rarity as a number or in the printed "7 (R)" notation, which marks the template as restricted
+func (t *CraftingTemplate) UnmarshalJSON(data []byte) error {
+	type template CraftingTemplate
+	return decodeRarityJSON(data, (*template)(t), &t.Rarity, &t.Restricted)
+}
+
+// CraftRequest is the request body used to craft an item from a template with the outcome of the crafting check.
+// Successes and advantages are net results, negative advantages are net threat.
+type CraftRequest struct {
+	TemplateID    primitive.ObjectID     `json:"templateId"`
+	Name          string                 `json:"name"`
+	Successes     int64                  `json:"successes"`
+	Advantages    int64                  `json:"advantages"`
+	Triumphs      int64                  `json:"triumphs"`
+	Despairs      int64                  `json:"despairs"`
+	Modifications []CraftingModification `json:"modifications"`
+}
+
+// Validate checks the craft request
+func (c *CraftRequest) Validate() error {
+	validationErr := ValidationError{}
+
+	if c.TemplateID.IsZero() {
+		validationErr.Add("templateId", "is required")
+	}
+	if c.Triumphs < 0 {
+		validationErr.Add("triumphs", "must not be negative")
+	}
+	if c.Despairs < 0 {
+		validationErr.Add("despairs", "must not be negative")
+	}
+
+	return validationErr.OrNil()
+}
+
+// CraftResult is the outcome of crafting an item, a failed check produces no item
+type CraftResult struct {
+	Crafted         bool                   `json:"crafted"`
+	Kind            ItemKind               `json:"kind"`
+	ItemID          string                 `json:"itemId,omitempty"`
+	MaterialPrice   int64                  `json:"materialPrice"`
+	TimeHours       int64                  `json:"timeHours"`
+	AdvantagesSpent int64                  `json:"advantagesSpent"`
+	Modifications   []CraftingModification `json:"modifications"`
+	Weapon          *Weapon                `json:"weapon,omitempty"`
+	Armor           *Armor                 `json:"armor,omitempty"`
+}
+
+// Craft builds a new item from the template. The check needs at least one net success, otherwise the materials
+// are spent without producing an item. Advantages, with every triumph worth TriumphAdvantages, pay for the requested
+// modifications and a despair leaves the item Inferior. The new item still has to be validated and inserted.
+func (t *CraftingTemplate) Craft(request CraftRequest) (CraftResult, error) {
+	result := CraftResult{
+		Kind:          t.Kind,
+		MaterialPrice: t.MaterialPrice,
+		TimeHours:     t.TimeHours,
+		Modifications: []CraftingModification{},
+	}
+
+	costs := CraftingModifications[t.Kind]
+	budget := request.Advantages + request.Triumphs*TriumphAdvantages
+	validationErr := ValidationError{}
+	for i, modification := range request.Modifications {
+		cost, ok := costs[modification]
+		if !ok {
+			validationErr.Add(fmt.Sprintf("modifications[%d]", i), fmt.Sprintf("%v cannot be applied to %v", modification, t.Kind))
+			continue
+		}
+		result.AdvantagesSpent += cost
+	}
+	if len(validationErr) == 0 && result.AdvantagesSpent > budget {
+		validationErr.Add("modifications", fmt.Sprintf("cost %v advantages but only %v are available", result.AdvantagesSpent, budget))
+	}
+	if len(validationErr) > 0 {
+		return CraftResult{}, validationErr
+	}
+
+	if request.Successes < 1 {
+		result.AdvantagesSpent = 0
+		return result, nil
+	}
+	result.Crafted = true
+	result.Modifications = append(result.Modifications, request.Modifications...)
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		name = t.Name
+	}
+
+	switch t.Kind {
+	case WeaponKind:
+		weapon := *t.Weapon
+		weapon.ID = primitive.NewObjectID()
+		weapon.Name = name
+		weapon.Qualities = append([]Quality{}, t.Weapon.Qualities...)
+		weapon.Attachments = nil
+		weapon.Restricted = weapon.Restricted || t.Restricted
+		applyWeaponModifications(&weapon, request.Modifications)
+		if request.Despairs > 0 {
+			weapon.Qualities = addQuality(weapon.Qualities, "Inferior", 0)
+		}
+		weapon.Special = FormatQualities(weapon.Qualities)
+		result.ItemID = weapon.ID.Hex()
+		result.Weapon = &weapon
+	case ArmorKind:
+		armor := *t.Armor
+		armor.ID = primitive.NewObjectID()
+		armor.ArmorType = name
+		armor.Qualities = append([]Quality{}, t.Armor.Qualities...)
+		armor.Attachments = nil
+		armor.Restricted = armor.Restricted || t.Restricted
+		applyArmorModifications(&armor, request.Modifications)
+		if request.Despairs > 0 {
+			armor.Qualities = addQuality(armor.Qualities, "Inferior", 0)
+		}
+		armor.Special = FormatQualities(armor.Qualities)
+		result.ItemID = armor.ID.Hex()
+		result.Armor = &armor
+	}
+
+	return result, nil
+}
+
+// applyWeaponModifications improves the weapon, the Critical rating never drops below 1 and encumbrance never below 0
+func applyWeaponModifications(weapon *Weapon, modifications []CraftingModification) {
+	for _, modification := range modifications {
+		switch modification {
+		case DamageModification:
+			weapon.Damage.Base++
+		case CriticalModification:
+			if weapon.Critical > 1 {
+				weapon.Critical--
+			}
+		case AccurateModification:
+			weapon.Qualities = addQuality(weapon.Qualities, "Accurate", 1)
+		case EncumbranceModification:
+			if weapon.Encumberence > 0 {
+				weapon.Encumberence--
+			}
+		case HardPointsModification:
+			weapon.HP++
+		}
+	}
+}
+
+// applyArmorModifications improves the armor, encumbrance never drops below 0
+func applyArmorModifications(armor *Armor, modifications []CraftingModification) {
+	for _, modification := range modifications {
+		switch modification {
+		case SoakModification:
+			armor.Soak++
+		case DefenseModification:
+			armor.Defense++
+		case EncumbranceModification:
+			if armor.Encumbrance > 0 {
+				armor.Encumbrance--
+			}
+		case HardPointsModification:
+			armor.HardPoints++
+		}
+	}
+}
+
+// addQuality adds a quality or raises the rating of a quality the item already has
+func addQuality(qualities []Quality, name string, rating int64) []Quality {
+	for i := range qualities {
+		if strings.EqualFold(qualities[i].Name, name) {
+			qualities[i].Rating += rating
+			return qualities
+		}
+	}
+
+	definition, _ := LookupQuality(name)
+	return append(qualities, Quality{Name: definition.Name, Rating: rating, Active: definition.Active})
+}
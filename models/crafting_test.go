@@ -0,0 +1,95 @@
+package model
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func weaponTemplate() CraftingTemplate {
+	return CraftingTemplate{
+		Name:          "Holdout Blaster Frame",
+		Kind:          WeaponKind,
+		Frame:         "Pistol",
+		MaterialPrice: 150,
+		Rarity:        3,
+		Difficulty:    int64(Average),
+		TimeHours:     8,
+		Weapon: &Weapon{
+			Skill:        "Ranged (Light)",
+			Damage:       Damage{Base: 5},
+			Critical:     4,
+			Range:        Short,
+			Encumberence: 1,
+			HP:           1,
+			Price:        200,
+			Qualities:    []Quality{{Name: "Stun Setting"}},
+		},
+	}
+}
+
+func TestCraftingTemplate_Validate(t *testing.T) {
+	template := weaponTemplate()
+	template.Kind = "WEAPON"
+	template.Armor = &Armor{}
+
+	err := template.Validate()
+	if err != nil || template.Kind != WeaponKind || template.Armor != nil {
+		t.Errorf("Validate() error:\ngot: %+v, %v\nexpected a canonical weapon template", template, err)
+	}
+
+	invalid := CraftingTemplate{Kind: ArmorKind, Difficulty: 7}
+	err = invalid.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 3 {
+		t.Errorf("Validate() error:\ngot: %v\nexpected name, difficulty and armor errors", err)
+	}
+}
+
+func TestCraftingTemplate_Craft(t *testing.T) {
+	template := weaponTemplate()
+
+	result, err := template.Craft(CraftRequest{
+		TemplateID:    primitive.NewObjectID(),
+		Name:          "Custom Holdout",
+		Successes:     2,
+		Advantages:    2,
+		Triumphs:      1,
+		Despairs:      1,
+		Modifications: []CraftingModification{DamageModification, AccurateModification},
+	})
+	if err != nil || !result.Crafted || result.Weapon == nil || result.AdvantagesSpent != 5 {
+		t.Fatalf("Craft() error:\ngot: %+v, %v\nexpected a crafted weapon", result, err)
+	}
+
+	weapon := result.Weapon
+	if weapon.Name != "Custom Holdout" || weapon.Damage.Base != 6 || weapon.ID.IsZero() || weapon.Special != "Stun Setting, Accurate 1, Inferior" {
+		t.Errorf("Craft() error:\ngot: %+v\nexpected the modifications and Inferior quality applied", weapon)
+	}
+	if template.Weapon.Damage.Base != 5 || len(template.Weapon.Qualities) != 1 {
+		t.Errorf("Craft() error:\ngot: %+v\nexpected the template to be unchanged", template.Weapon)
+	}
+}
+
+func TestCraftingTemplate_Craft_Failed(t *testing.T) {
+	template := weaponTemplate()
+
+	result, err := template.Craft(CraftRequest{Successes: 0, Advantages: 3, Modifications: []CraftingModification{DamageModification}})
+	if err != nil || result.Crafted || result.Weapon != nil || result.TimeHours != 8 {
+		t.Errorf("Craft() error:\ngot: %+v, %v\nexpected no item from a failed check", result, err)
+	}
+}
+
+func TestCraftingTemplate_Craft_InvalidModifications(t *testing.T) {
+	template := weaponTemplate()
+
+	_, err := template.Craft(CraftRequest{Successes: 1, Advantages: 1, Modifications: []CraftingModification{DamageModification}})
+	if err == nil {
+		t.Errorf("Craft() error:\ngot: <nil>\nexpected modifications to cost more than the advantages")
+	}
+
+	_, err = template.Craft(CraftRequest{Successes: 1, Advantages: 9, Modifications: []CraftingModification{SoakModification}})
+	if err == nil {
+		t.Errorf("Craft() error:\ngot: <nil>\nexpected soak to be rejected for a weapon")
+	}
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Damage is a weapon's damage, brawn relative damage such as "+1" is added to the wielder's Brawn
+type Damage struct {
+	Base          int64 `json:"base" bson:"base"`
+	BrawnRelative bool  `json:"brawnRelative" bson:"brawnRelative"`
+}
+
+// ParseDamage parses the printed damage notation, "+1" is brawn relative and "6" is absolute
+func ParseDamage(damage string) (Damage, error) {
+	trimmed := strings.TrimSpace(damage)
+	if trimmed == "" || trimmed == "-" {
+		return Damage{}, nil
+	}
+
+	brawnRelative := strings.HasPrefix(trimmed, "+")
+	base, err := strconv.ParseInt(strings.TrimPrefix(trimmed, "+"), 10, 64)
+	if err != nil {
+		return Damage{}, fmt.Errorf("%v is not a valid damage value", damage)
+	}
+
+	return Damage{Base: base, BrawnRelative: brawnRelative}, nil
+}
+
+// String renders the damage in the printed notation
+func (d Damage) String() string {
+	if d.BrawnRelative {
+		return "+" + strconv.FormatInt(d.Base, 10)
+	}
+	return strconv.FormatInt(d.Base, 10)
+}
+
+// Effective returns the damage dealt by a wielder with the given Brawn
+func (d Damage) Effective(brawn int64) int64 {
+	if d.BrawnRelative {
+		return d.Base + brawn
+	}
+	return d.Base
+}
+
+// MarshalJSON keeps the legacy string form in responses
+func (d Damage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts the legacy string form, a bare number or a {base, brawnRelative} object
+func (d *Damage) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		parsed, err := ParseDamage(text)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	}
+
+	var base int64
+	if err := json.Unmarshal(data, &base); err == nil {
+		*d = Damage{Base: base}
+		return nil
+	}
+
+	type damage Damage
+	var structured damage
+	if err := json.Unmarshal(data, &structured); err != nil {
+		return fmt.Errorf("%s is not a valid damage value", data)
+	}
+	*d = Damage(structured)
+	return nil
+}
+
+// UnmarshalBSONValue reads both the stored {base, brawnRelative} document and the legacy string form
+func (d *Damage) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+
+	switch t {
+	case bsontype.String:
+		parsed, err := ParseDamage(raw.StringValue())
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	case bsontype.Int32:
+		*d = Damage{Base: int64(raw.Int32())}
+	case bsontype.Int64:
+		*d = Damage{Base: raw.Int64()}
+	case bsontype.Double:
+		*d = Damage{Base: int64(raw.Double())}
+	case bsontype.EmbeddedDocument:
+		type damage Damage
+		var structured damage
+		if err := raw.Unmarshal(&structured); err != nil {
+			return err
+		}
+		*d = Damage(structured)
+	case bsontype.Null, bsontype.Undefined:
+		*d = Damage{}
+	default:
+		return fmt.Errorf("cannot decode %v into damage", t)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseDamage(t *testing.T) {
+	cases := map[string]Damage{
+		"+1": {Base: 1, BrawnRelative: true},
+		"6":  {Base: 6},
+		" ":  {},
+	}
+
+	for input, expected := range cases {
+		damage, err := ParseDamage(input)
+		if err != nil || damage != expected {
+			t.Errorf("ParseDamage(%q) error:\ngot: %v, %v\nexpected: %v", input, damage, err, expected)
+		}
+	}
+
+	if _, err := ParseDamage("lots"); err == nil {
+		t.Errorf("ParseDamage() error:\ngot: <nil>\nexpected: error")
+	}
+}
+
+func TestDamage_Effective(t *testing.T) {
+	if damage := (Damage{Base: 1, BrawnRelative: true}).Effective(3); damage != 4 {
+		t.Errorf("Effective() error:\ngot: %v\nexpected: %v", damage, 4)
+	}
+	if damage := (Damage{Base: 6}).Effective(3); damage != 6 {
+		t.Errorf("Effective() error:\ngot: %v\nexpected: %v", damage, 6)
+	}
+}
+
+func TestDamage_JSON(t *testing.T) {
+	weapon := Weapon{}
+	err := json.Unmarshal([]byte(`{"damage": "+2"}`), &weapon)
+	if err != nil || weapon.Damage != (Damage{Base: 2, BrawnRelative: true}) {
+		t.Errorf("UnmarshalJSON() error:\ngot: %v, %v\nexpected: +2", weapon.Damage, err)
+	}
+
+	for _, input := range []string{`{"damage": 7}`, `{"damage": {"base": 7}}`} {
+		weapon := Weapon{}
+		err := json.Unmarshal([]byte(input), &weapon)
+		if err != nil || weapon.Damage != (Damage{Base: 7}) {
+			t.Errorf("UnmarshalJSON(%v) error:\ngot: %v, %v\nexpected: 7", input, weapon.Damage, err)
+		}
+	}
+
+	output, _ := json.Marshal(Damage{Base: 1, BrawnRelative: true})
+	if string(output) != `"+1"` {
+		t.Errorf("MarshalJSON() error:\ngot: %s\nexpected: %v", output, `"+1"`)
+	}
+}
+
+func TestDamage_BSON(t *testing.T) {
+	legacy, _ := bson.Marshal(bson.M{"damage": "+1"})
+	weapon := Weapon{}
+	err := bson.Unmarshal(legacy, &weapon)
+	if err != nil || weapon.Damage != (Damage{Base: 1, BrawnRelative: true}) {
+		t.Errorf("UnmarshalBSONValue() error:\ngot: %v, %v\nexpected: +1", weapon.Damage, err)
+	}
+
+	stored, _ := bson.Marshal(Weapon{Damage: Damage{Base: 6}})
+	roundTrip := Weapon{}
+	err = bson.Unmarshal(stored, &roundTrip)
+	if err != nil || roundTrip.Damage != (Damage{Base: 6}) {
+		t.Errorf("UnmarshalBSONValue() error:\ngot: %v, %v\nexpected: 6", roundTrip.Damage, err)
+	}
+
+	base, err := bson.Raw(stored).LookupErr("damage", "base")
+	if err != nil || base.Int64() != 6 {
+		t.Errorf("MarshalBSON() error:\ngot: %v, %v\nexpected numeric damage.base", base, err)
+	}
+}
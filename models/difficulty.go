@@ -0,0 +1,32 @@
+package model
+
+// Difficulty is the number of difficulty dice added to a check
+type Difficulty int64
+
+// The check difficulties from the core rulebook
+const (
+	Simple Difficulty = iota
+	Easy
+	Average
+	Hard
+	Daunting
+	Formidable
+)
+
+var difficultyNames = []string{"Simple", "Easy", "Average", "Hard", "Daunting", "Formidable"}
+
+// String returns the name of the difficulty
+func (d Difficulty) String() string {
+	if d < Simple {
+		d = Simple
+	}
+	if d > Formidable {
+		d = Formidable
+	}
+	return difficultyNames[d]
+}
+
+// Dice returns the number of difficulty dice rolled for the check
+func (d Difficulty) Dice() int64 {
+	return int64(d)
+}
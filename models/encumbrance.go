@@ -0,0 +1,126 @@
+package model
+
+import "fmt"
+
+// The FFG encumbrance rules
+const (
+	BaseEncumbranceThreshold int64 = 5
+	WornArmorReduction       int64 = 3
+)
+
+// EncumbranceBonus raises the encumbrance threshold, e.g. a backpack or the Burly talent
+type EncumbranceBonus struct {
+	Source string `json:"source"`
+	Value  int64  `json:"value"`
+}
+
+// EncumbranceRequest is the request body used to calculate the encumbrance of a character
+type EncumbranceRequest struct {
+	Brawn   int64              `json:"brawn"`
+	Bonuses []EncumbranceBonus `json:"bonuses"`
+	Items   []InventoryItem    `json:"items"`
+}
+
+// EncumbranceLine is the encumbrance a single requested item adds to the load, Encumbrance is that of one item and for
+// worn armor that of the suit being worn
+type EncumbranceLine struct {
+	Kind        ItemKind  `json:"kind"`
+	ItemID      string    `json:"itemId"`
+	Name        string    `json:"name"`
+	Quantity    int64     `json:"quantity"`
+	State       ItemState `json:"state"`
+	Encumbrance int64     `json:"encumbrance"`
+	Total       int64     `json:"total"`
+}
+
+// EncumbranceReport is the result of an encumbrance calculation
+type EncumbranceReport struct {
+	Threshold        int64             `json:"threshold"`
+	Load             int64             `json:"load"`
+	Setback          int64             `json:"setback"`
+	OverEncumbered   bool              `json:"overEncumbered"`
+	FreeManeuverLost bool              `json:"freeManeuverLost"`
+	Items            []EncumbranceLine `json:"items"`
+}
+
+// Validate checks the encumbrance request, items without a state are carried
+func (e *EncumbranceRequest) Validate() error {
+	validationErr := ValidationError{}
+
+	if e.Brawn < 1 {
+		validationErr.Add("brawn", "must be at least 1")
+	}
+	for index := range e.Items {
+		validateInventoryItem(fmt.Sprintf("items[%d]", index), &e.Items[index], &validationErr)
+	}
+
+	return validationErr.OrNil()
+}
+
+// Threshold returns the encumbrance threshold, 5 plus Brawn plus any bonuses
+func (e *EncumbranceRequest) Threshold() int64 {
+	threshold := BaseEncumbranceThreshold + e.Brawn
+	for _, bonus := range e.Bonuses {
+		threshold += bonus.Value
+	}
+	return threshold
+}
+
+// Calculate totals the load of the expanded items against the threshold.
+// Stowed items are not carried and add nothing, worn armor counts 3 less than its listed encumbrance. Only one suit of
+// an equipped armor line is worn, the rest of its quantity is carried at the listed encumbrance.
+// Every point over the threshold adds a setback die to Agility and Brawn checks,
+// exceeding it by Brawn or more also costs the character their free maneuver.
+func (e *EncumbranceRequest) Calculate() EncumbranceReport {
+	report := EncumbranceReport{
+		Threshold: e.Threshold(),
+		Items:     make([]EncumbranceLine, 0, len(e.Items)),
+	}
+
+	for _, item := range e.Items {
+		var reduction int64
+		line := EncumbranceLine{
+			Kind:     item.Kind,
+			ItemID:   item.ItemID.Hex(),
+			Quantity: item.Quantity,
+			State:    item.State,
+		}
+
+		switch {
+		case item.Weapon != nil:
+			line.Name = item.Weapon.Name
+			line.Encumbrance = item.Weapon.Encumberence
+		case item.Armor != nil:
+			line.Name = item.Armor.ArmorType
+			line.Encumbrance = item.Armor.Encumbrance
+			if item.State == Equipped {
+				reduction = WornArmorReduction
+			}
+		case item.Gear != nil:
+			line.Name = item.Gear.Name
+			line.Encumbrance = item.Gear.Encumbrance
+		}
+		if line.Encumbrance < 0 {
+			line.Encumbrance = 0
+		}
+		carried := line.Encumbrance
+		line.Encumbrance -= reduction
+		if line.Encumbrance < 0 {
+			line.Encumbrance = 0
+		}
+
+		if item.State != Stowed {
+			line.Total = line.Encumbrance + carried*(line.Quantity-1)
+		}
+		report.Load += line.Total
+		report.Items = append(report.Items, line)
+	}
+
+	if report.Load > report.Threshold {
+		report.OverEncumbered = true
+		report.Setback = report.Load - report.Threshold
+		report.FreeManeuverLost = report.Setback >= e.Brawn
+	}
+
+	return report
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEncumbranceRequest_Calculate(t *testing.T) {
+	request := EncumbranceRequest{
+		Brawn:   2,
+		Bonuses: []EncumbranceBonus{{Source: "Backpack", Value: 3}},
+		Items: []InventoryItem{
+			{Kind: WeaponKind, Quantity: 1, State: Equipped, Weapon: &Weapon{Name: "Heavy Blaster Rifle", Encumberence: 6}},
+			{Kind: ArmorKind, Quantity: 1, State: Equipped, Armor: &Armor{ArmorType: "Laminate", Encumbrance: 4}},
+			{Kind: ArmorKind, Quantity: 1, State: Carried, Armor: &Armor{ArmorType: "Padded Armor", Encumbrance: 2}},
+			{Kind: GearKind, Quantity: 3, State: Carried, Gear: &Gear{Name: "Stimpack", Encumbrance: 1}},
+			{Kind: WeaponKind, Quantity: 1, State: Stowed, Weapon: &Weapon{Name: "Missile Tube", Encumberence: 7}},
+		},
+	}
+
+	report := request.Calculate()
+	if report.Threshold != 10 || report.Load != 12 {
+		t.Errorf("Calculate() error:\ngot: threshold %v load %v\nexpected: threshold 10 load 12", report.Threshold, report.Load)
+	}
+	if report.Items[1].Encumbrance != 1 || report.Items[4].Total != 0 {
+		t.Errorf("Calculate() error:\ngot: %+v\nexpected: worn armor reduced by 3 and stowed items ignored", report.Items)
+	}
+	if !report.OverEncumbered || report.Setback != 2 || !report.FreeManeuverLost {
+		t.Errorf("Calculate() error:\ngot: %+v\nexpected: 2 setback dice and the free maneuver lost", report)
+	}
+}
+
+func TestEncumbranceRequest_Calculate_WithinThreshold(t *testing.T) {
+	request := EncumbranceRequest{
+		Brawn: 3,
+		Items: []InventoryItem{
+			{Kind: ArmorKind, Quantity: 1, State: Equipped, Armor: &Armor{ArmorType: "Padded Armor", Encumbrance: 2}},
+			{Kind: WeaponKind, Quantity: 1, State: Carried, Weapon: &Weapon{Name: "Blaster Pistol", Encumberence: 1}},
+		},
+	}
+
+	report := request.Calculate()
+	if report.Items[0].Encumbrance != 0 || report.Load != 1 || report.OverEncumbered || report.Setback != 0 || report.FreeManeuverLost {
+		t.Errorf("Calculate() error:\ngot: %+v\nexpected: load of 1 within the threshold", report)
+	}
+}
+
+func TestEncumbranceRequest_Calculate_SpareArmor(t *testing.T) {
+	request := EncumbranceRequest{
+		Brawn: 3,
+		Items: []InventoryItem{
+			{Kind: ArmorKind, Quantity: 3, State: Equipped, Armor: &Armor{ArmorType: "Laminate", Encumbrance: 4}},
+		},
+	}
+
+	// one suit is worn at 1, the two spare suits are carried at 4 each
+	report := request.Calculate()
+	if report.Items[0].Encumbrance != 1 || report.Items[0].Total != 9 || report.Load != 9 {
+		t.Errorf("Calculate() error:\ngot: %+v\nexpected: a load of 9 with the reduction applied once", report)
+	}
+}
+
+func TestEncumbranceRequest_Validate(t *testing.T) {
+	request := EncumbranceRequest{Items: []InventoryItem{{Kind: "weapon", ItemID: primitive.NewObjectID(), Quantity: 1}}}
+
+	err := request.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 1 || validationErr[0].Field != "brawn" {
+		t.Errorf("Validate() error:\ngot: %v\nexpected: brawn error", err)
+	}
+	if request.Items[0].Kind != WeaponKind || request.Items[0].State != Carried {
+		t.Errorf("Validate() error:\ngot: %+v\nexpected: canonical kind and carried state", request.Items[0])
+	}
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Gear struct is used to create a general equipment object such as a comlink, medpac or stimpack
+type Gear struct {
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	Name        string             `json:"name" bson:"name"`
+	Category    string             `json:"category" bson:"category"`
+	Price       int64              `json:"price" bson:"price"`
+	Encumbrance int64              `json:"encumbrance" bson:"encumbrance"`
+	Rarity      int64              `json:"rarity" bson:"rarity"`
+	Restricted  bool               `json:"restricted" bson:"restricted"`
+	Description string             `json:"description" bson:"description"`
+	Consumable  bool               `json:"consumable" bson:"consumable"`
+	Uses        int64              `json:"uses" bson:"uses"`
+}
+
+// Validate checks the gear before it is written to the database
+func (g *Gear) Validate() error {
+	validationErr := ValidationError{}
+
+	if strings.TrimSpace(g.Name) == "" {
+		validationErr.Add("name", "is required")
+	}
+	if g.Price < 0 {
+		validationErr.Add("price", "must not be negative")
+	}
+	if g.Encumbrance < 0 {
+		validationErr.Add("encumbrance", "must not be negative")
+	}
+	validateRarity(g.Rarity, &validationErr)
+
+	if g.Uses < 0 {
+		validationErr.Add("uses", "must not be negative")
+	}
+	if g.Consumable && g.Uses < 1 {
+		validationErr.Add("uses", "consumable gear must have at least 1 use")
+	}
+
+	return validationErr.OrNil()
+}
+
+// UnmarshalJSON accepts the printed rarity notation, "7 (R)" also marks the gear as restricted
+func (g *Gear) UnmarshalJSON(data []byte) error {
+	type gear Gear
+	return decodeRarityJSON(data, (*gear)(g), &g.Rarity, &g.Restricted)
+}
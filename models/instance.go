@@ -0,0 +1,267 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CompletedModification is a modification option of an installed attachment completed Count times
+type CompletedModification struct {
+	Option int64 `json:"option" bson:"option"`
+	Count  int64 `json:"count" bson:"count"`
+}
+
+// InstanceAttachment is an attachment installed on an item instance with the modifications completed on it
+type InstanceAttachment struct {
+	InstalledAttachment `bson:",inline"`
+	Modifications       []CompletedModification `json:"modifications" bson:"modifications,omitempty"`
+}
+
+// ItemInstance is a character's own copy of a catalog weapon or armor, the catalog document stays the unmodified template
+type ItemInstance struct {
+	ID          primitive.ObjectID   `json:"_id" bson:"_id"`
+	CharacterID string               `json:"characterId" bson:"characterId"`
+	Kind        ItemKind             `json:"kind" bson:"kind"`
+	ItemID      primitive.ObjectID   `json:"itemId" bson:"itemId"`
+	CustomName  string               `json:"customName" bson:"customName"`
+	Attachments []InstanceAttachment `json:"attachments" bson:"attachments"`
+	Notes       string               `json:"notes" bson:"notes"`
+}
+
+// Validate checks the instance before it is written to the database, attachments are checked against the catalog by ValidateAttachments
+func (i *ItemInstance) Validate() error {
+	validationErr := ValidationError{}
+
+	if strings.TrimSpace(i.CharacterID) == "" {
+		validationErr.Add("characterId", "is required")
+	}
+
+	kind, err := parseKind(string(i.Kind), AttachableKinds)
+	if err != nil {
+		validationErr.Add("kind", err.Error())
+	}
+	i.Kind = kind
+
+	if i.ItemID.IsZero() {
+		validationErr.Add("itemId", "is required")
+	}
+
+	// updates set the whole document, an instance without attachments stores an empty list
+	if i.Attachments == nil {
+		i.Attachments = []InstanceAttachment{}
+	}
+	for index, attachment := range i.Attachments {
+		field := fmt.Sprintf("attachments[%d]", index)
+		if attachment.AttachmentID.IsZero() {
+			validationErr.Add(field+".attachmentId", "is required")
+		}
+		for m, modification := range attachment.Modifications {
+			if modification.Count < 1 {
+				validationErr.Add(fmt.Sprintf("%v.modifications[%d].count", field, m), "must be at least 1")
+			}
+		}
+	}
+
+	return validationErr.OrNil()
+}
+
+// ValidateAttachments checks the installed attachments against their catalog documents, copying the attachment names and
+// hard points onto the instance. Every attachment must fit the item kind, the completed modifications must exist on the
+// attachment and not exceed their count, and the attachments must fit in the hard points left free on the catalog item.
+func (i *ItemInstance) ValidateAttachments(hardPoints int64, attachments map[primitive.ObjectID]*Attachment) error {
+	validationErr := ValidationError{}
+
+	completed := map[primitive.ObjectID]map[int64]int64{}
+	installed := []InstalledAttachment{}
+	for index := range i.Attachments {
+		field := fmt.Sprintf("attachments[%d]", index)
+		instanceAttachment := &i.Attachments[index]
+
+		attachment, ok := attachments[instanceAttachment.AttachmentID]
+		if !ok {
+			validationErr.Add(field+".attachmentId", fmt.Sprintf("attachment %v does not exist", instanceAttachment.AttachmentID.Hex()))
+			continue
+		}
+		if !attachment.Allows(i.Kind) {
+			validationErr.Add(field+".attachmentId", fmt.Sprintf("attachment %v cannot be installed on %v", attachment.Name, i.Kind))
+		}
+		instanceAttachment.InstalledAttachment = attachment.Installed()
+		installed = append(installed, instanceAttachment.InstalledAttachment)
+
+		if completed[attachment.ID] == nil {
+			completed[attachment.ID] = map[int64]int64{}
+		}
+		for m, modification := range instanceAttachment.Modifications {
+			modField := fmt.Sprintf("%v.modifications[%d]", field, m)
+			if modification.Option < 0 || modification.Option >= int64(len(attachment.ModificationOptions)) {
+				validationErr.Add(modField+".option", fmt.Sprintf("attachment %v has %v modification options", attachment.Name, len(attachment.ModificationOptions)))
+				continue
+			}
+
+			completed[attachment.ID][modification.Option] += modification.Count
+			if limit := attachment.ModificationOptions[modification.Option].Count; completed[attachment.ID][modification.Option] > limit {
+				validationErr.Add(modField+".count", fmt.Sprintf("%v can only be completed %v times", attachment.ModificationOptions[modification.Option].Description, limit))
+			}
+		}
+	}
+
+	validateHardPoints(hardPoints, installed, &validationErr)
+
+	return validationErr.OrNil()
+}
+
+// EffectiveStats are the stats of an item instance after its attachments and modifications are applied
+type EffectiveStats struct {
+	InstanceID     primitive.ObjectID `json:"instanceId"`
+	Kind           ItemKind           `json:"kind"`
+	ItemID         primitive.ObjectID `json:"itemId"`
+	Name           string             `json:"name"`
+	Damage         *Damage            `json:"damage,omitempty"`
+	Critical       int64              `json:"critical,omitempty"`
+	Soak           int64              `json:"soak"`
+	Defense        int64              `json:"defense"`
+	Encumbrance    int64              `json:"encumbrance"`
+	HardPoints     int64              `json:"hardPoints"`
+	UsedHardPoints int64              `json:"usedHardPoints"`
+	Qualities      []Quality          `json:"qualities"`
+	Modifiers      []Modifier         `json:"modifiers"`
+	Warnings       []string           `json:"warnings,omitempty"`
+}
+
+// Modifiers returns every modifier applied to the instance, the attachment base modifiers and each completed modification.
+// Attachments deleted from the catalog are skipped with a warning.
+func (i *ItemInstance) Modifiers(attachments map[primitive.ObjectID]*Attachment) ([]Modifier, []string, error) {
+	modifiers := []Modifier{}
+	warnings := []string{}
+	for _, installed := range i.Attachments {
+		attachment, ok := attachments[installed.AttachmentID]
+		if !ok {
+			warnings = append(warnings, missingAttachment(installed.InstalledAttachment))
+			continue
+		}
+
+		modifiers = append(modifiers, attachment.baseModifier())
+
+		for _, modification := range installed.Modifications {
+			if modification.Option < 0 || modification.Option >= int64(len(attachment.ModificationOptions)) {
+				return nil, nil, fmt.Errorf("attachment %v has no modification option %v", attachment.Name, modification.Option)
+			}
+			option := attachment.ModificationOptions[modification.Option].Modifier
+			for n := int64(0); n < modification.Count; n++ {
+				modifiers = append(modifiers, option)
+			}
+		}
+	}
+	return modifiers, warnings, nil
+}
+
+// CatalogModifiers returns the base modifiers of the attachments installed on the catalog item, every instance of the item
+// carries them. The stats count the hard points of these attachments as used so their modifiers are applied as well, their
+// modifications are only tracked on instances. Attachments deleted from the catalog are skipped with a warning.
+func CatalogModifiers(installed []InstalledAttachment, attachments map[primitive.ObjectID]*Attachment) ([]Modifier, []string) {
+	modifiers := []Modifier{}
+	warnings := []string{}
+	for _, catalogAttachment := range installed {
+		attachment, ok := attachments[catalogAttachment.AttachmentID]
+		if !ok {
+			warnings = append(warnings, missingAttachment(catalogAttachment))
+			continue
+		}
+		modifiers = append(modifiers, attachment.baseModifier())
+	}
+	return modifiers, warnings
+}
+
+// missingAttachment is the warning for an installed attachment that is no longer in the catalog
+func missingAttachment(installed InstalledAttachment) string {
+	return fmt.Sprintf("attachment %v (%v) is no longer in the catalog, its modifiers are not applied", installed.Name, installed.AttachmentID.Hex())
+}
+
+// baseModifier returns the modifier the attachment applies once installed, described by the attachment name when it has no description
+func (a *Attachment) baseModifier() Modifier {
+	base := a.BaseModifier
+	if base.Description == "" {
+		base.Description = a.Name
+	}
+	return base
+}
+
+// WeaponStats applies the modifiers to the catalog weapon, critical ratings never drop below 1. The modifiers are expected
+// to start with the CatalogModifiers of the weapon's own attachments.
+func (i *ItemInstance) WeaponStats(weapon Weapon, modifiers []Modifier) EffectiveStats {
+	damage := weapon.Damage
+	stats := EffectiveStats{
+		Critical:       weapon.Critical,
+		Encumbrance:    weapon.Encumberence,
+		HardPoints:     weapon.HP,
+		UsedHardPoints: UsedHardPoints(weapon.Attachments),
+		Qualities:      append([]Quality{}, weapon.Qualities...),
+	}
+	stats.Damage = &damage
+
+	i.applyModifiers(&stats, weapon.Name, modifiers)
+	if stats.Critical < 1 {
+		stats.Critical = 1
+	}
+	if stats.Damage.Base < 0 {
+		stats.Damage.Base = 0
+	}
+	return stats
+}
+
+// ArmorStats applies the modifiers to the catalog armor, the modifiers are expected to start with the CatalogModifiers of the
+// armor's own attachments
+func (i *ItemInstance) ArmorStats(armor Armor, modifiers []Modifier) EffectiveStats {
+	stats := EffectiveStats{
+		Soak:           armor.Soak,
+		Defense:        armor.Defense,
+		Encumbrance:    armor.Encumbrance,
+		HardPoints:     armor.HardPoints,
+		UsedHardPoints: UsedHardPoints(armor.Attachments),
+		Qualities:      append([]Quality{}, armor.Qualities...),
+	}
+
+	i.applyModifiers(&stats, armor.ArmorType, modifiers)
+	return stats
+}
+
+// applyModifiers adds up the modifiers, stats that cannot be negative are floored at 0
+func (i *ItemInstance) applyModifiers(stats *EffectiveStats, name string, modifiers []Modifier) {
+	stats.InstanceID = i.ID
+	stats.Kind = i.Kind
+	stats.ItemID = i.ItemID
+	stats.Name = name
+	if strings.TrimSpace(i.CustomName) != "" {
+		stats.Name = i.CustomName
+	}
+	stats.Modifiers = modifiers
+
+	for _, installed := range i.Attachments {
+		stats.UsedHardPoints += installed.HardPoints
+	}
+
+	for _, modifier := range modifiers {
+		if stats.Damage != nil {
+			stats.Damage.Base += modifier.Damage
+		}
+		stats.Critical += modifier.Critical
+		stats.Soak += modifier.Soak
+		stats.Defense += modifier.Defense
+		stats.Encumbrance += modifier.Encumbrance
+		for _, quality := range modifier.Qualities {
+			stats.Qualities = addQuality(stats.Qualities, quality.Name, quality.Rating)
+		}
+	}
+
+	if stats.Soak < 0 {
+		stats.Soak = 0
+	}
+	if stats.Defense < 0 {
+		stats.Defense = 0
+	}
+	if stats.Encumbrance < 0 {
+		stats.Encumbrance = 0
+	}
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func scope() *Attachment {
+	return &Attachment{
+		ID:           primitive.NewObjectID(),
+		Name:         "Telescopic Optical Sight",
+		HardPoints:   1,
+		AllowedKinds: []ItemKind{WeaponKind},
+		BaseModifier: Modifier{Description: "Reduce difficulty of long range checks"},
+		ModificationOptions: []ModificationOption{
+			{Modifier: Modifier{Description: "Accurate +1", Qualities: []Quality{{Name: "Accurate", Rating: 1}}}, Count: 1},
+			{Modifier: Modifier{Description: "Damage +1", Damage: 1}, Count: 2},
+			{Modifier: Modifier{Description: "Critical -1", Critical: -1}, Count: 5},
+		},
+	}
+}
+
+func TestItemInstance_ValidateAttachments(t *testing.T) {
+	attachment := scope()
+	instance := ItemInstance{
+		CharacterID: "char-1",
+		Kind:        WeaponKind,
+		ItemID:      primitive.NewObjectID(),
+		Attachments: []InstanceAttachment{{
+			InstalledAttachment: InstalledAttachment{AttachmentID: attachment.ID},
+			Modifications:       []CompletedModification{{Option: 1, Count: 2}},
+		}},
+	}
+	attachments := map[primitive.ObjectID]*Attachment{attachment.ID: attachment}
+
+	err := instance.ValidateAttachments(1, attachments)
+	if err != nil || instance.Attachments[0].Name != attachment.Name || instance.Attachments[0].HardPoints != 1 {
+		t.Errorf("ValidateAttachments() error:\ngot: %+v, %v\nexpected the attachment record to be filled in", instance.Attachments[0], err)
+	}
+
+	instance.Attachments[0].Modifications = append(instance.Attachments[0].Modifications, CompletedModification{Option: 1, Count: 1}, CompletedModification{Option: 7, Count: 1})
+	err = instance.ValidateAttachments(0, attachments)
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 3 {
+		t.Errorf("ValidateAttachments() error:\ngot: %v\nexpected count, option and hard point errors", err)
+	}
+}
+
+func TestItemInstance_WeaponStats(t *testing.T) {
+	attachment := scope()
+	instance := ItemInstance{
+		Kind:       WeaponKind,
+		CustomName: "Old Reliable",
+		Attachments: []InstanceAttachment{{
+			InstalledAttachment: attachment.Installed(),
+			Modifications:       []CompletedModification{{Option: 0, Count: 1}, {Option: 1, Count: 2}, {Option: 2, Count: 5}},
+		}},
+	}
+	weapon := Weapon{Name: "Blaster Rifle", Damage: Damage{Base: 9}, Critical: 3, Encumberence: 4, HP: 4, Qualities: []Quality{{Name: "Stun Setting", Active: true}}}
+
+	modifiers, warnings, err := instance.Modifiers(map[primitive.ObjectID]*Attachment{attachment.ID: attachment})
+	if err != nil || len(modifiers) != 9 || len(warnings) != 0 {
+		t.Fatalf("Modifiers() error:\ngot: %v, %v\nexpected 9 modifiers", len(modifiers), err)
+	}
+
+	stats := instance.WeaponStats(weapon, modifiers)
+	if stats.Name != "Old Reliable" || stats.Damage.Base != 11 || stats.Critical != 1 || stats.Encumbrance != 4 || stats.UsedHardPoints != 1 || len(stats.Qualities) != 2 {
+		t.Errorf("WeaponStats() error:\ngot: %+v\nexpected damage 11, critical floored at 1 and Accurate 1 added", stats)
+	}
+	if weapon.Damage.Base != 9 || len(weapon.Qualities) != 1 {
+		t.Errorf("WeaponStats() error:\ngot: %+v\nexpected the catalog weapon to be unchanged", weapon)
+	}
+}
+
+func TestItemInstance_ArmorStats(t *testing.T) {
+	attachment := &Attachment{ID: primitive.NewObjectID(), Name: "Armor Plating", HardPoints: 2, BaseModifier: Modifier{Soak: 1, Encumbrance: 1},
+		ModificationOptions: []ModificationOption{{Modifier: Modifier{Defense: 1}, Count: 1}}}
+	instance := ItemInstance{Kind: ArmorKind, Attachments: []InstanceAttachment{{
+		InstalledAttachment: attachment.Installed(),
+		Modifications:       []CompletedModification{{Option: 0, Count: 1}},
+	}}}
+
+	modifiers, _, _ := instance.Modifiers(map[primitive.ObjectID]*Attachment{attachment.ID: attachment})
+	stats := instance.ArmorStats(Armor{ArmorType: "Padded Armor", Soak: 2, Encumbrance: 2, HardPoints: 2}, modifiers)
+	if stats.Name != "Padded Armor" || stats.Soak != 3 || stats.Defense != 1 || stats.Encumbrance != 3 || stats.UsedHardPoints != 2 || stats.Damage != nil {
+		t.Errorf("ArmorStats() error:\ngot: %+v\nexpected soak 3, defense 1 and encumbrance 3", stats)
+	}
+}
+
+func TestCatalogModifiers(t *testing.T) {
+	attachment := scope()
+	installed := []InstalledAttachment{attachment.Installed(), {AttachmentID: primitive.NewObjectID(), Name: "Retired Sight", HardPoints: 1}}
+
+	modifiers, warnings := CatalogModifiers(installed, map[primitive.ObjectID]*Attachment{attachment.ID: attachment})
+	if len(modifiers) != 1 || modifiers[0].Description != attachment.BaseModifier.Description {
+		t.Errorf("CatalogModifiers() error:\ngot: %+v\nexpected the scope base modifier only", modifiers)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Retired Sight") {
+		t.Errorf("CatalogModifiers() error:\ngot: %v\nexpected a warning naming the retired sight", warnings)
+	}
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ItemState is where a character keeps an inventory item
+type ItemState string
+
+// The inventory item states
+const (
+	Equipped ItemState = "equipped"
+	Carried  ItemState = "carried"
+	Stowed   ItemState = "stowed"
+)
+
+// ItemStates lists every inventory item state
+var ItemStates = []ItemState{Equipped, Carried, Stowed}
+
+// ParseItemState returns the item state matching the given name, ignoring case
+func ParseItemState(name string) (ItemState, error) {
+	for _, state := range ItemStates {
+		if strings.EqualFold(strings.TrimSpace(name), string(state)) {
+			return state, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not an item state, expected one of %v", name, ItemStates)
+}
+
+// InventoryItem is a reference to a catalog item held by a character
+type InventoryItem struct {
+	Kind     ItemKind           `json:"kind" bson:"kind"`
+	ItemID   primitive.ObjectID `json:"itemId" bson:"itemId"`
+	Quantity int64              `json:"quantity" bson:"quantity"`
+	State    ItemState          `json:"state" bson:"state"`
+	Notes    string             `json:"notes" bson:"notes"`
+	Weapon   *Weapon            `json:"weapon,omitempty" bson:"-"`
+	Armor    *Armor             `json:"armor,omitempty" bson:"-"`
+	Gear     *Gear              `json:"gear,omitempty" bson:"-"`
+}
+
+// Inventory struct is used to hold the items of a character kept in another service
+type Inventory struct {
+	CharacterID string          `json:"characterId" bson:"_id"`
+	Items       []InventoryItem `json:"items" bson:"items"`
+}
+
+// Validate checks the inventory before it is written to the database, items without a state are carried
+func (i *Inventory) Validate() error {
+	validationErr := ValidationError{}
+
+	if strings.TrimSpace(i.CharacterID) == "" {
+		validationErr.Add("characterId", "is required")
+	}
+
+	for index := range i.Items {
+		validateInventoryItem(fmt.Sprintf("items[%d]", index), &i.Items[index], &validationErr)
+	}
+
+	return validationErr.OrNil()
+}
+
+// validateInventoryItem checks a single item reference and canonicalizes its kind and state
+func validateInventoryItem(field string, item *InventoryItem, validationErr *ValidationError) {
+	kind, err := ParseItemKind(string(item.Kind))
+	if err != nil {
+		validationErr.Add(field+".kind", err.Error())
+	} else {
+		item.Kind = kind
+	}
+
+	if item.ItemID.IsZero() {
+		validationErr.Add(field+".itemId", "is required")
+	}
+	if item.Quantity < 1 {
+		validationErr.Add(field+".quantity", "must be at least 1")
+	}
+
+	if item.State == "" {
+		item.State = Carried
+	}
+	state, err := ParseItemState(string(item.State))
+	if err != nil {
+		validationErr.Add(field+".state", err.Error())
+	} else {
+		item.State = state
+	}
+
+	item.Weapon, item.Armor, item.Gear = nil, nil, nil
+}
@@ -0,0 +1,211 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AdversaryType is the kind of NPC the loot is dropped by
+type AdversaryType string
+
+// The adversary types
+const (
+	Minion  AdversaryType = "minion"
+	Rival   AdversaryType = "rival"
+	Nemesis AdversaryType = "nemesis"
+)
+
+// AdversaryTypes lists every adversary type
+var AdversaryTypes = []AdversaryType{Minion, Rival, Nemesis}
+
+// ParseAdversaryType returns the adversary type matching the given name, ignoring case
+func ParseAdversaryType(name string) (AdversaryType, error) {
+	for _, adversary := range AdversaryTypes {
+		if strings.EqualFold(strings.TrimSpace(name), string(adversary)) {
+			return adversary, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not an adversary type, expected one of %v", name, AdversaryTypes)
+}
+
+// The loot table limits, MaxLootDraws caps the draws of a single roll across every nested table
+const (
+	MaxLootRolls     = 20
+	MaxLootQuantity  = 100
+	MaxLootNestDepth = 8
+	MaxLootDraws     = 1000
+	MaxLootWeight    = 1000000
+)
+
+// LootConditions restrict when an entry can be drawn, an empty list matches every roll
+type LootConditions struct {
+	Adversaries []AdversaryType `json:"adversaries,omitempty" bson:"adversaries,omitempty"`
+	Locations   []Location      `json:"locations,omitempty" bson:"locations,omitempty"`
+}
+
+// Matches reports whether the roll satisfies the conditions
+func (c LootConditions) Matches(request LootRollRequest) bool {
+	if len(c.Adversaries) > 0 {
+		found := false
+		for _, adversary := range c.Adversaries {
+			found = found || adversary == request.Adversary
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(c.Locations) > 0 {
+		found := false
+		for _, location := range c.Locations {
+			found = found || location == request.Location
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LootEntry is a weighted outcome of a loot table, either a catalog item or another table to roll on
+type LootEntry struct {
+	Kind        ItemKind            `json:"kind,omitempty" bson:"kind,omitempty"`
+	ItemID      *primitive.ObjectID `json:"itemId,omitempty" bson:"itemId,omitempty"`
+	TableID     *primitive.ObjectID `json:"tableId,omitempty" bson:"tableId,omitempty"`
+	Weight      int64               `json:"weight" bson:"weight"`
+	MinQuantity int64               `json:"minQuantity" bson:"minQuantity"`
+	MaxQuantity int64               `json:"maxQuantity" bson:"maxQuantity"`
+	Conditions  LootConditions      `json:"conditions" bson:"conditions"`
+}
+
+// LootTable is a named list of weighted entries, every roll on the table draws Rolls entries
+type LootTable struct {
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	Name        string             `json:"name" bson:"name"`
+	Description string             `json:"description" bson:"description"`
+	Rolls       int64              `json:"rolls" bson:"rolls"`
+	Entries     []LootEntry        `json:"entries" bson:"entries"`
+}
+
+// Validate checks the loot table before it is written to the database, applying the default roll count and quantities
+func (t *LootTable) Validate() error {
+	validationErr := ValidationError{}
+
+	if strings.TrimSpace(t.Name) == "" {
+		validationErr.Add("name", "is required")
+	}
+	if t.Rolls == 0 {
+		t.Rolls = 1
+	}
+	if t.Rolls < 1 || t.Rolls > MaxLootRolls {
+		validationErr.Add("rolls", fmt.Sprintf("must be between 1 and %v", MaxLootRolls))
+	}
+	if len(t.Entries) == 0 {
+		validationErr.Add("entries", "must contain at least one entry")
+	}
+
+	for i := range t.Entries {
+		validateLootEntry(fmt.Sprintf("entries[%v]", i), &t.Entries[i], t.ID, &validationErr)
+	}
+
+	return validationErr.OrNil()
+}
+
+func validateLootEntry(field string, entry *LootEntry, tableID primitive.ObjectID, validationErr *ValidationError) {
+	switch {
+	case entry.TableID != nil && entry.ItemID != nil:
+		validationErr.Add(field, "must reference either an item or a table, not both")
+	case entry.TableID != nil:
+		if *entry.TableID == tableID {
+			validationErr.Add(field+".tableId", "must not reference the table itself")
+		}
+		entry.Kind = ""
+	case entry.ItemID != nil:
+		kind, err := ParseItemKind(string(entry.Kind))
+		if err != nil || kind == GearKind {
+			validationErr.Add(field+".kind", fmt.Sprintf("must be one of %v", AttachableKinds))
+		}
+		entry.Kind = kind
+	default:
+		validationErr.Add(field, "must reference an item or a table")
+	}
+
+	if entry.Weight < 1 || entry.Weight > MaxLootWeight {
+		validationErr.Add(field+".weight", fmt.Sprintf("must be between 1 and %v", MaxLootWeight))
+	}
+
+	if entry.MinQuantity == 0 {
+		entry.MinQuantity = 1
+	}
+	if entry.MaxQuantity == 0 {
+		entry.MaxQuantity = entry.MinQuantity
+	}
+	if entry.MinQuantity < 1 || entry.MaxQuantity > MaxLootQuantity || entry.MinQuantity > entry.MaxQuantity {
+		validationErr.Add(field+".quantity", fmt.Sprintf("must be a range between 1 and %v", MaxLootQuantity))
+	}
+
+	for _, adversary := range entry.Conditions.Adversaries {
+		if _, err := ParseAdversaryType(string(adversary)); err != nil {
+			validationErr.Add(field+".conditions.adversaries", err.Error())
+		}
+	}
+	for i, location := range entry.Conditions.Locations {
+		name, _, err := ParseLocationModifier(string(location))
+		if err != nil || name == "" {
+			validationErr.Add(field+".conditions.locations", fmt.Sprintf("%v is not a location", location))
+			continue
+		}
+		entry.Conditions.Locations[i] = name
+	}
+}
+
+// LootRollRequest is the optional request body of a loot roll describing who dropped the loot and where
+type LootRollRequest struct {
+	Adversary AdversaryType `json:"adversary"`
+	Location  Location      `json:"location"`
+}
+
+// Validate checks the roll request, normalizing the adversary and location names
+func (r *LootRollRequest) Validate() error {
+	validationErr := ValidationError{}
+
+	if r.Adversary != "" {
+		adversary, err := ParseAdversaryType(string(r.Adversary))
+		if err != nil {
+			validationErr.Add("adversary", err.Error())
+		}
+		r.Adversary = adversary
+	}
+
+	if r.Location != "" {
+		name, _, err := ParseLocationModifier(string(r.Location))
+		if err != nil || name == "" {
+			validationErr.Add("location", fmt.Sprintf("%v is not a location", r.Location))
+		}
+		r.Location = name
+	}
+
+	return validationErr.OrNil()
+}
+
+// LootDrop is a concrete item produced by a loot roll, Table is the name of the table that dropped it
+type LootDrop struct {
+	Kind     ItemKind           `json:"kind"`
+	ItemID   primitive.ObjectID `json:"itemId"`
+	Name     string             `json:"name"`
+	Quantity int64              `json:"quantity"`
+	Table    string             `json:"table"`
+	Weapon   *Weapon            `json:"weapon,omitempty"`
+	Armor    *Armor             `json:"armor,omitempty"`
+}
+
+// LootRoll is the result of rolling on a loot table
+type LootRoll struct {
+	TableID primitive.ObjectID `json:"tableId"`
+	Table   string             `json:"table"`
+	Seed    int64              `json:"seed"`
+	Items   []LootDrop         `json:"items"`
+}
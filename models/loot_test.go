@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLootTable_Validate(t *testing.T) {
+	itemID := primitive.NewObjectID()
+	table := LootTable{
+		ID:   primitive.NewObjectID(),
+		Name: "Stormtrooper Squad",
+		Entries: []LootEntry{{
+			Kind:       "Weapon",
+			ItemID:     &itemID,
+			Weight:     3,
+			Conditions: LootConditions{Adversaries: []AdversaryType{Minion}, Locations: []Location{"outer-rim"}},
+		}},
+	}
+
+	err := table.Validate()
+	entry := table.Entries[0]
+	if err != nil || table.Rolls != 1 || entry.Kind != WeaponKind || entry.MinQuantity != 1 || entry.MaxQuantity != 1 || entry.Conditions.Locations[0] != OuterRim {
+		t.Errorf("Validate() error:\ngot: %+v, %v\nexpected a table with defaults applied", table, err)
+	}
+}
+
+func TestLootTable_Validate_Invalid(t *testing.T) {
+	tableID := primitive.NewObjectID()
+	itemID := primitive.NewObjectID()
+	table := LootTable{
+		ID: tableID,
+		Entries: []LootEntry{
+			{TableID: &tableID, Weight: 1},
+			{Kind: GearKind, ItemID: &itemID, Weight: 0, MinQuantity: 3, MaxQuantity: 2},
+			{Weight: 1, Conditions: LootConditions{Adversaries: []AdversaryType{"boss"}}},
+		},
+	}
+
+	err := table.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 7 {
+		t.Errorf("Validate() error:\ngot: %v\nexpected 7 field errors", err)
+	}
+}
+
+func TestLootTable_Validate_Weight(t *testing.T) {
+	first, second := primitive.NewObjectID(), primitive.NewObjectID()
+	table := LootTable{
+		ID:   primitive.NewObjectID(),
+		Name: "Hutt Vault",
+		Entries: []LootEntry{
+			{Kind: WeaponKind, ItemID: &first, Weight: 1 << 62},
+			{Kind: WeaponKind, ItemID: &second, Weight: 1 << 62},
+		},
+	}
+
+	err := table.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 2 {
+		t.Errorf("Validate() error:\ngot: %v\nexpected 2 weight errors", err)
+	}
+}
+
+func TestLootConditions_Matches(t *testing.T) {
+	conditions := LootConditions{Adversaries: []AdversaryType{Rival, Nemesis}, Locations: []Location{WildSpace}}
+
+	if !conditions.Matches(LootRollRequest{Adversary: Nemesis, Location: WildSpace}) {
+		t.Errorf("Matches() error:\ngot: false\nexpected: true")
+	}
+	if conditions.Matches(LootRollRequest{Adversary: Minion, Location: WildSpace}) {
+		t.Errorf("Matches() error:\ngot: true for a minion\nexpected: false")
+	}
+	if conditions.Matches(LootRollRequest{Adversary: Rival}) {
+		t.Errorf("Matches() error:\ngot: true without a location\nexpected: false")
+	}
+	if !(LootConditions{}).Matches(LootRollRequest{}) {
+		t.Errorf("Matches() error:\ngot: false for empty conditions\nexpected: true")
+	}
+}
@@ -0,0 +1,43 @@
+package model
+
+// Page is the envelope returned by paginated list endpoints, PageSize is the pageCount requested and TotalPages the
+// number of pages of that size the total fills
+type Page struct {
+	Items      interface{} `json:"items"`
+	Total      int64       `json:"total"`
+	TotalPages int         `json:"totalPages"`
+	PageNumber int         `json:"pageNumber"`
+	PageSize   int         `json:"pageSize"`
+	HasNext    bool        `json:"hasNext"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+// NewPage wraps one page of items with the paging details, HasNext is set when items remain after this page
+func NewPage(items interface{}, total int64, pageNumber, pageCount int) Page {
+	page := Page{
+		Items:      items,
+		Total:      total,
+		PageNumber: pageNumber,
+		PageSize:   pageCount,
+		HasNext:    int64(pageNumber)*int64(pageCount) < total,
+	}
+	page.TotalPages = page.LastPage()
+	return page
+}
+
+// LastPage returns the number of the last page, an empty result still has a first page
+func (p Page) LastPage() int {
+	if p.Total == 0 || p.PageSize < 1 {
+		return 1
+	}
+	return int((p.Total + int64(p.PageSize) - 1) / int64(p.PageSize))
+}
+
+// WithCursor adds the cursor that continues after this page, a page read from a cursor has a next page only when another cursor follows
+func (p Page) WithCursor(nextCursor string, fromCursor bool) Page {
+	p.NextCursor = nextCursor
+	if fromCursor {
+		p.HasNext = nextCursor != ""
+	}
+	return p
+}
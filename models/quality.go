@@ -0,0 +1,159 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Quality is a structured item quality such as "Pierce 2" or "Stun Setting"
+type Quality struct {
+	Name   string `json:"name" bson:"name"`
+	Rating int64  `json:"rating,omitempty" bson:"rating,omitempty"`
+	Active bool   `json:"active" bson:"active"`
+}
+
+// QualityDefinition describes an official FFG item quality
+type QualityDefinition struct {
+	Name   string
+	Active bool
+	Rated  bool
+}
+
+// QualityRegistry holds the official FFG item qualities keyed by their lower case name
+var QualityRegistry = map[string]QualityDefinition{
+	"accurate":     {Name: "Accurate", Rated: true},
+	"auto-fire":    {Name: "Auto-fire", Active: true},
+	"blast":        {Name: "Blast", Active: true, Rated: true},
+	"breach":       {Name: "Breach", Rated: true},
+	"burn":         {Name: "Burn", Active: true, Rated: true},
+	"concussive":   {Name: "Concussive", Active: true, Rated: true},
+	"cortosis":     {Name: "Cortosis"},
+	"cumbersome":   {Name: "Cumbersome", Rated: true},
+	"defensive":    {Name: "Defensive", Rated: true},
+	"deflection":   {Name: "Deflection", Rated: true},
+	"disorient":    {Name: "Disorient", Active: true, Rated: true},
+	"ensnare":      {Name: "Ensnare", Active: true, Rated: true},
+	"guided":       {Name: "Guided", Active: true, Rated: true},
+	"inaccurate":   {Name: "Inaccurate", Rated: true},
+	"inferior":     {Name: "Inferior"},
+	"ion":          {Name: "Ion"},
+	"knockdown":    {Name: "Knockdown", Active: true},
+	"limited ammo": {Name: "Limited Ammo", Rated: true},
+	"linked":       {Name: "Linked", Active: true, Rated: true},
+	"pierce":       {Name: "Pierce", Rated: true},
+	"prepare":      {Name: "Prepare", Rated: true},
+	"reinforced":   {Name: "Reinforced"},
+	"slow-firing":  {Name: "Slow-Firing", Rated: true},
+	"stun":         {Name: "Stun", Active: true, Rated: true},
+	"stun damage":  {Name: "Stun Damage"},
+	"stun setting": {Name: "Stun Setting", Active: true},
+	"sunder":       {Name: "Sunder", Active: true},
+	"superior":     {Name: "Superior"},
+	"tractor":      {Name: "Tractor", Rated: true},
+	"unwieldy":     {Name: "Unwieldy", Rated: true},
+	"vicious":      {Name: "Vicious", Rated: true},
+}
+
+// LookupQuality returns the registry definition for a quality name, ignoring case and surrounding space
+func LookupQuality(name string) (QualityDefinition, bool) {
+	definition, ok := QualityRegistry[strings.ToLower(strings.TrimSpace(name))]
+	return definition, ok
+}
+
+// ParseQualities parses the legacy special string, e.g. "Disorient 3, Stun Setting", into qualities.
+// Every entry is returned even when it is not in the registry so legacy documents stay readable,
+// the returned error lists the entries that could not be validated.
+func ParseQualities(special string) ([]Quality, error) {
+	qualities := []Quality{}
+	validationErr := ValidationError{}
+
+	trimmed := strings.TrimSpace(special)
+	if trimmed == "" || trimmed == "-" || strings.EqualFold(trimmed, "none") {
+		return qualities, nil
+	}
+
+	for _, entry := range strings.FieldsFunc(trimmed, func(r rune) bool { return r == ',' || r == ';' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		quality := Quality{Name: entry}
+		if split := strings.LastIndex(entry, " "); split > 0 {
+			rating, err := strconv.ParseInt(entry[split+1:], 10, 64)
+			if err == nil {
+				quality.Name = strings.TrimSpace(entry[:split])
+				quality.Rating = rating
+			}
+		}
+
+		if definition, ok := LookupQuality(quality.Name); ok {
+			quality.Name = definition.Name
+			quality.Active = definition.Active
+		} else {
+			validationErr.Add("special", fmt.Sprintf("unknown quality %v", entry))
+		}
+
+		qualities = append(qualities, quality)
+	}
+
+	return qualities, validationErr.OrNil()
+}
+
+// FormatQualities renders qualities back to the legacy special string
+func FormatQualities(qualities []Quality) string {
+	entries := make([]string, 0, len(qualities))
+	for _, quality := range qualities {
+		if quality.Rating > 0 {
+			entries = append(entries, quality.Name+" "+strconv.FormatInt(quality.Rating, 10))
+		} else {
+			entries = append(entries, quality.Name)
+		}
+	}
+	return strings.Join(entries, ", ")
+}
+
+// ValidateQualities checks every quality against the registry and canonicalizes names and activation in place
+func ValidateQualities(qualities []Quality) error {
+	validationErr := ValidationError{}
+
+	for i := range qualities {
+		field := fmt.Sprintf("qualities[%d]", i)
+		definition, ok := LookupQuality(qualities[i].Name)
+		if !ok {
+			validationErr.Add(field, fmt.Sprintf("unknown quality %v", qualities[i].Name))
+			continue
+		}
+
+		qualities[i].Name = definition.Name
+		qualities[i].Active = definition.Active
+
+		if definition.Rated && qualities[i].Rating < 1 {
+			validationErr.Add(field, fmt.Sprintf("%v requires a rating of at least 1", definition.Name))
+		}
+		if !definition.Rated && qualities[i].Rating != 0 {
+			validationErr.Add(field, fmt.Sprintf("%v does not take a rating", definition.Name))
+		}
+	}
+
+	return validationErr.OrNil()
+}
+
+// normalizeQualities keeps the structured qualities and the legacy special string in step.
+// Structured qualities win when both are present.
+func normalizeQualities(special *string, qualities *[]Quality) error {
+	if len(*qualities) > 0 {
+		err := ValidateQualities(*qualities)
+		*special = FormatQualities(*qualities)
+		return err
+	}
+
+	parsed, err := ParseQualities(*special)
+	*qualities = parsed
+	if err != nil {
+		return err
+	}
+
+	return ValidateQualities(*qualities)
+}
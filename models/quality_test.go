@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestParseQualities(t *testing.T) {
+	qualities, err := ParseQualities("disorient 3, Stun Setting; Pierce 2")
+	if err != nil {
+		t.Errorf("ParseQualities() error:\ngot: %v\nexpected: <nil>", err)
+	}
+
+	expected := []Quality{
+		{Name: "Disorient", Rating: 3, Active: true},
+		{Name: "Stun Setting", Active: true},
+		{Name: "Pierce", Rating: 2},
+	}
+	if len(qualities) != len(expected) {
+		t.Fatalf("ParseQualities() error:\ngot: %v\nexpected: %v", qualities, expected)
+	}
+	for i := range expected {
+		if qualities[i] != expected[i] {
+			t.Errorf("ParseQualities() error:\ngot: %v\nexpected: %v", qualities[i], expected[i])
+		}
+	}
+}
+
+func TestParseQualities_Empty(t *testing.T) {
+	for _, special := range []string{"", " ", "-", "None"} {
+		qualities, err := ParseQualities(special)
+		if err != nil || len(qualities) != 0 {
+			t.Errorf("ParseQualities(%q) error:\ngot: %v, %v\nexpected: [], <nil>", special, qualities, err)
+		}
+	}
+}
+
+func TestParseQualities_Unknown(t *testing.T) {
+	qualities, err := ParseQualities("Pierce 2, Sparkly 4")
+	if err == nil {
+		t.Errorf("ParseQualities() error:\ngot: <nil>\nexpected: validation error")
+	}
+	if len(qualities) != 2 || qualities[1].Name != "Sparkly" || qualities[1].Rating != 4 {
+		t.Errorf("ParseQualities() error:\ngot: %v\nexpected unknown qualities to be kept", qualities)
+	}
+}
+
+func TestFormatQualities(t *testing.T) {
+	special := FormatQualities([]Quality{{Name: "Pierce", Rating: 2}, {Name: "Cortosis"}})
+	if special != "Pierce 2, Cortosis" {
+		t.Errorf("FormatQualities() error:\ngot: %v\nexpected: %v", special, "Pierce 2, Cortosis")
+	}
+}
+
+func TestValidateQualities(t *testing.T) {
+	qualities := []Quality{{Name: "pierce", Rating: 2}, {Name: "auto-fire"}}
+	err := ValidateQualities(qualities)
+	if err != nil {
+		t.Errorf("ValidateQualities() error:\ngot: %v\nexpected: <nil>", err)
+	}
+	if qualities[0].Name != "Pierce" || qualities[1].Name != "Auto-fire" || !qualities[1].Active {
+		t.Errorf("ValidateQualities() error:\ngot: %v\nexpected canonical names", qualities)
+	}
+}
+
+func TestValidateQualities_Invalid(t *testing.T) {
+	err := ValidateQualities([]Quality{{Name: "Pierce"}, {Name: "Cortosis", Rating: 1}, {Name: "Sparkly"}})
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 3 {
+		t.Errorf("ValidateQualities() error:\ngot: %v\nexpected: 3 field errors", err)
+	}
+}
+
+func TestWeapon_NormalizeQualities(t *testing.T) {
+	legacy := Weapon{Special: "Disorient 3"}
+	err := legacy.NormalizeQualities()
+	if err != nil || len(legacy.Qualities) != 1 || legacy.Qualities[0].Name != "Disorient" {
+		t.Errorf("NormalizeQualities() error:\ngot: %v, %v\nexpected qualities parsed from special", legacy.Qualities, err)
+	}
+
+	structured := Weapon{Special: "stale", Qualities: []Quality{{Name: "Breach", Rating: 1}}}
+	err = structured.NormalizeQualities()
+	if err != nil || structured.Special != "Breach 1" {
+		t.Errorf("NormalizeQualities() error:\ngot: %v, %v\nexpected: Breach 1", structured.Special, err)
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RangeBand is one of the FFG range bands a weapon can reach
+type RangeBand string
+
+// The FFG range bands from closest to farthest
+const (
+	Engaged RangeBand = "Engaged"
+	Short   RangeBand = "Short"
+	Medium  RangeBand = "Medium"
+	Long    RangeBand = "Long"
+	Extreme RangeBand = "Extreme"
+)
+
+// RangeBands lists every range band in order from closest to farthest
+var RangeBands = []RangeBand{Engaged, Short, Medium, Long, Extreme}
+
+// ParseRangeBand returns the range band matching the given name, ignoring case
+func ParseRangeBand(name string) (RangeBand, error) {
+	for _, band := range RangeBands {
+		if strings.EqualFold(strings.TrimSpace(name), string(band)) {
+			return band, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not a range band, expected one of %v", name, RangeBands)
+}
+
+// Rank returns the position of the range band from closest to farthest, or -1 when it is not a range band
+func (r RangeBand) Rank() int {
+	for i, band := range RangeBands {
+		if band == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// AtLeast returns every range band as far or farther than this one
+func (r RangeBand) AtLeast() []RangeBand {
+	if rank := r.Rank(); rank >= 0 {
+		return RangeBands[rank:]
+	}
+	return []RangeBand{}
+}
+
+// AtMost returns every range band as close or closer than this one
+func (r RangeBand) AtMost() []RangeBand {
+	if rank := r.Rank(); rank >= 0 {
+		return RangeBands[:rank+1]
+	}
+	return []RangeBand{}
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRangeBand(t *testing.T) {
+	band, err := ParseRangeBand(" medium")
+	if err != nil || band != Medium {
+		t.Errorf("ParseRangeBand() error:\ngot: %v, %v\nexpected: %v", band, err, Medium)
+	}
+
+	if _, err := ParseRangeBand("Planetary"); err == nil {
+		t.Errorf("ParseRangeBand() error:\ngot: <nil>\nexpected: error")
+	}
+}
+
+func TestRangeBand_Ordering(t *testing.T) {
+	if Engaged.Rank() != 0 || Extreme.Rank() != 4 || RangeBand("Planetary").Rank() != -1 {
+		t.Errorf("Rank() error:\ngot: %v, %v\nexpected: 0, 4", Engaged.Rank(), Extreme.Rank())
+	}
+	if bands := Long.AtLeast(); !reflect.DeepEqual(bands, []RangeBand{Long, Extreme}) {
+		t.Errorf("AtLeast() error:\ngot: %v\nexpected: [Long Extreme]", bands)
+	}
+	if bands := Short.AtMost(); !reflect.DeepEqual(bands, []RangeBand{Engaged, Short}) {
+		t.Errorf("AtMost() error:\ngot: %v\nexpected: [Engaged Short]", bands)
+	}
+}
+
+func TestWeapon_Validate_Range(t *testing.T) {
+	weapon := Weapon{Range: "long"}
+	if err := weapon.Validate(); err != nil || weapon.Range != Long {
+		t.Errorf("Validate() error:\ngot: %v, %v\nexpected: %v, <nil>", weapon.Range, err, Long)
+	}
+
+	weapon = Weapon{Range: "Planetary"}
+	err := weapon.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 1 || validationErr[0].Field != "range" {
+		t.Errorf("Validate() error:\ngot: %v\nexpected: range field error", err)
+	}
+}
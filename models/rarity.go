@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxRarity is the highest rarity printed in the FFG books
+const MaxRarity = 10
+
+var printedRarity = regexp.MustCompile(`^(\d+)\s*(\(\s*[rR]\s*\))?$`)
+
+// ParseRarity parses the printed rarity notation, "7 (R)" is rarity 7 and restricted
+func ParseRarity(rarity string) (int64, bool, error) {
+	matches := printedRarity.FindStringSubmatch(strings.TrimSpace(rarity))
+	if matches == nil {
+		return 0, false, fmt.Errorf("%v is not a valid rarity", rarity)
+	}
+
+	value, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%v is not a valid rarity", rarity)
+	}
+
+	return value, matches[2] != "", nil
+}
+
+// FormatRarity renders a rarity in the printed notation
+func FormatRarity(rarity int64, restricted bool) string {
+	if restricted {
+		return strconv.FormatInt(rarity, 10) + " (R)"
+	}
+	return strconv.FormatInt(rarity, 10)
+}
+
+// decodeRarity reads a JSON rarity that is either a number or the printed notation
+func decodeRarity(data json.RawMessage) (int64, bool, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return 0, false, nil
+	}
+
+	var value int64
+	if err := json.Unmarshal(data, &value); err == nil {
+		return value, false, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return 0, false, fmt.Errorf("%s is not a valid rarity", data)
+	}
+	return ParseRarity(text)
+}
+
+// decodeRarityJSON decodes a JSON document whose rarity is a number or in the printed notation. The other fields are
+// decoded into document, an alias of the model type so its own UnmarshalJSON is not called again, then the rarity is set
+// and the printed restricted mark is added to restricted.
+func decodeRarityJSON(data []byte, document interface{}, rarity *int64, restricted *bool) error {
+	fields := map[string]json.RawMessage{}
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	var rawRarity json.RawMessage
+	for key, value := range fields {
+		// field names match case-insensitively like the rest of the document
+		if strings.EqualFold(key, "rarity") {
+			rawRarity = value
+			delete(fields, key)
+		}
+	}
+
+	remaining, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(remaining, document)
+	if err != nil {
+		return err
+	}
+
+	value, printedRestricted, err := decodeRarity(rawRarity)
+	if err != nil {
+		return err
+	}
+
+	*rarity = value
+	*restricted = *restricted || printedRestricted
+	return nil
+}
+
+// validateRarity checks the rarity is on the printed scale
+func validateRarity(rarity int64, validationErr *ValidationError) {
+	if rarity < 0 || rarity > MaxRarity {
+		validationErr.Add("rarity", fmt.Sprintf("must be between 0 and %v", MaxRarity))
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseRarity(t *testing.T) {
+	rarity, restricted, err := ParseRarity("7 (R)")
+	if err != nil || rarity != 7 || !restricted {
+		t.Errorf("ParseRarity() error:\ngot: %v, %v, %v\nexpected: 7, true, <nil>", rarity, restricted, err)
+	}
+
+	rarity, restricted, err = ParseRarity("4")
+	if err != nil || rarity != 4 || restricted {
+		t.Errorf("ParseRarity() error:\ngot: %v, %v, %v\nexpected: 4, false, <nil>", rarity, restricted, err)
+	}
+
+	if _, _, err := ParseRarity("rare"); err == nil {
+		t.Errorf("ParseRarity() error:\ngot: <nil>\nexpected: error")
+	}
+}
+
+func TestFormatRarity(t *testing.T) {
+	if rarity := FormatRarity(7, true); rarity != "7 (R)" {
+		t.Errorf("FormatRarity() error:\ngot: %v\nexpected: 7 (R)", rarity)
+	}
+}
+
+func TestWeapon_UnmarshalJSON_Rarity(t *testing.T) {
+	weapon := Weapon{}
+	err := json.Unmarshal([]byte(`{"name": "Heavy Blaster Rifle", "damage": "10", "rarity": "6 (R)"}`), &weapon)
+	if err != nil || weapon.Rarity != 6 || !weapon.Restricted || weapon.Name != "Heavy Blaster Rifle" || weapon.Damage.Base != 10 {
+		t.Errorf("UnmarshalJSON() error:\ngot: %+v, %v\nexpected restricted rarity 6", weapon, err)
+	}
+
+	weapon = Weapon{}
+	err = json.Unmarshal([]byte(`{"rarity": 3, "restricted": true}`), &weapon)
+	if err != nil || weapon.Rarity != 3 || !weapon.Restricted {
+		t.Errorf("UnmarshalJSON() error:\ngot: %+v, %v\nexpected restricted rarity 3", weapon, err)
+	}
+}
+
+func TestArmor_UnmarshalJSON_Rarity(t *testing.T) {
+	armor := Armor{}
+	err := json.Unmarshal([]byte(`{"type": "Laminate", "rarity": "5"}`), &armor)
+	if err != nil || armor.Rarity != 5 || armor.Restricted || armor.ArmorType != "Laminate" {
+		t.Errorf("UnmarshalJSON() error:\ngot: %+v, %v\nexpected unrestricted rarity 5", armor, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"rarity": "rare"}`), &armor); err == nil {
+		t.Errorf("UnmarshalJSON() error:\ngot: <nil>\nexpected: error")
+	}
+}
+
+func TestCatalogModels_UnmarshalJSON_Rarity(t *testing.T) {
+	gear := Gear{}
+	err := json.Unmarshal([]byte(`{"name": "Stimpack", "Rarity": "1 (R)"}`), &gear)
+	if err != nil || gear.Rarity != 1 || !gear.Restricted || gear.Name != "Stimpack" {
+		t.Errorf("UnmarshalJSON() error:\ngot: %+v, %v\nexpected restricted rarity 1", gear, err)
+	}
+
+	vehicle := Vehicle{}
+	err = json.Unmarshal([]byte(`{"name": "Z-95 Headhunter", "silhouette": 3, "rarity": 4}`), &vehicle)
+	if err != nil || vehicle.Rarity != 4 || vehicle.Restricted || vehicle.Silhouette != 3 {
+		t.Errorf("UnmarshalJSON() error:\ngot: %+v, %v\nexpected unrestricted rarity 4", vehicle, err)
+	}
+
+	template := CraftingTemplate{}
+	err = json.Unmarshal([]byte(`{"name": "Blaster Pistol", "RARITY": "5 (R)"}`), &template)
+	if err != nil || template.Rarity != 5 || !template.Restricted || template.Name != "Blaster Pistol" {
+		t.Errorf("UnmarshalJSON() error:\ngot: %+v, %v\nexpected restricted rarity 5", template, err)
+	}
+}
+
+func TestArmor_Validate_Rarity(t *testing.T) {
+	armor := Armor{Rarity: 11}
+	err := armor.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 1 || validationErr[0].Field != "rarity" {
+		t.Errorf("Validate() error:\ngot: %v\nexpected: rarity field error", err)
+	}
+}
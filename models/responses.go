@@ -5,3 +5,9 @@ type HealthCheckResponse struct {
 	APIVersion string `json:"apiVersion"`
 	DBError    string `json:"dbError"`
 }
+
+//ValidationErrorResponse returns the validation message along with every invalid field
+type ValidationErrorResponse struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields"`
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SearchableKinds are the kinds of item a search looks through
+var SearchableKinds = []ItemKind{WeaponKind, ArmorKind}
+
+// MaxSearchResults is how deep a search can be paged, every searched collection reads its matches up to the end of the page
+const MaxSearchResults = 1000
+
+// SearchQuery is a full-text search across the item collections, Kinds limits the collections searched
+type SearchQuery struct {
+	Text  string
+	Kinds []ItemKind
+}
+
+// ParseSearchQuery reads the search text and the comma separated kinds to search, every searchable kind is searched when none are given
+func ParseSearchQuery(text, kinds string) (SearchQuery, error) {
+	validationErr := ValidationError{}
+	search := SearchQuery{Text: strings.TrimSpace(text)}
+
+	if search.Text == "" {
+		validationErr.Add("q", "is required")
+	}
+
+	for _, name := range strings.Split(kinds, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		kind, err := parseKind(name, SearchableKinds)
+		if err != nil {
+			validationErr.Add("kinds", fmt.Sprintf("%v cannot be searched, expected one of %v", strings.TrimSpace(name), SearchableKinds))
+			continue
+		}
+		if !search.Includes(kind) {
+			search.Kinds = append(search.Kinds, kind)
+		}
+	}
+	if len(search.Kinds) == 0 {
+		search.Kinds = SearchableKinds
+	}
+
+	return search, validationErr.OrNil()
+}
+
+// Includes reports whether the kind is searched
+func (q SearchQuery) Includes(kind ItemKind) bool {
+	for _, searched := range q.Kinds {
+		if searched == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchResult is an item matching a search, Score is its text search relevance and only the document of its kind is set
+type SearchResult struct {
+	Kind   ItemKind           `json:"kind"`
+	ItemID primitive.ObjectID `json:"itemId"`
+	Name   string             `json:"name"`
+	Score  float64            `json:"score"`
+	Weapon *Weapon            `json:"weapon,omitempty"`
+	Armor  *Armor             `json:"armor,omitempty"`
+}
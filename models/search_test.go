@@ -0,0 +1,27 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	search, err := ParseSearchQuery(" vibro ", "ARMOR, weapon,armor")
+	if err != nil || search.Text != "vibro" || !reflect.DeepEqual(search.Kinds, []ItemKind{ArmorKind, WeaponKind}) {
+		t.Errorf("ParseSearchQuery() error:\ngot: %+v, %v\nexpected: vibro across armor and weapons", search, err)
+	}
+
+	search, err = ParseSearchQuery("vibro", "")
+	if err != nil || !search.Includes(WeaponKind) || !search.Includes(ArmorKind) {
+		t.Errorf("ParseSearchQuery() error:\ngot: %+v, %v\nexpected every kind searched", search, err)
+	}
+}
+
+func TestParseSearchQuery_Invalid(t *testing.T) {
+	_, err := ParseSearchQuery("  ", "vehicle")
+
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 2 {
+		t.Errorf("ParseSearchQuery() error:\ngot: %v\nexpected: q and kinds errors", err)
+	}
+}
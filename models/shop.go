@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ShopSpecialty is the kind of store a shop is, it decides what the shop stocks and how it prices
+type ShopSpecialty string
+
+// The shop specialties
+const (
+	Armory           ShopSpecialty = "armory"
+	GeneralStore     ShopSpecialty = "general"
+	BlackMarketStore ShopSpecialty = "blackMarket"
+)
+
+// ShopSpecialties lists every shop specialty
+var ShopSpecialties = []ShopSpecialty{Armory, GeneralStore, BlackMarketStore}
+
+// ParseShopSpecialty returns the specialty matching the given name, ignoring case
+func ParseShopSpecialty(name string) (ShopSpecialty, error) {
+	for _, specialty := range ShopSpecialties {
+		if strings.EqualFold(strings.TrimSpace(name), string(specialty)) {
+			return specialty, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not a shop specialty, expected one of %v", name, ShopSpecialties)
+}
+
+// The shop size limits
+const (
+	DefaultShopSize = 10
+	MaxShopSize     = 100
+)
+
+// ShopRequest is the request body used to generate the stock of a shop.
+// The location is a location name or a raw rarity modifier, the seed makes the stock reproducible.
+type ShopRequest struct {
+	Name             string        `json:"name"`
+	Location         string        `json:"location"`
+	Specialty        ShopSpecialty `json:"specialty"`
+	Size             int64         `json:"size"`
+	PlanetModifier   float64       `json:"planetModifier"`
+	Seed             *int64        `json:"seed"`
+	LocationModifier int64         `json:"-"`
+}
+
+// Validate checks the shop request, parsing the location modifier and applying the default size
+func (s *ShopRequest) Validate() error {
+	validationErr := ValidationError{}
+
+	location, modifier, err := ParseLocationModifier(s.Location)
+	if err != nil {
+		validationErr.Add("location", err.Error())
+	}
+	if location != "" {
+		s.Location = string(location)
+	}
+	s.LocationModifier = modifier
+
+	specialty, err := ParseShopSpecialty(string(s.Specialty))
+	if err != nil {
+		validationErr.Add("specialty", err.Error())
+	}
+	s.Specialty = specialty
+
+	if s.Size == 0 {
+		s.Size = DefaultShopSize
+	}
+	if s.Size < 1 || s.Size > MaxShopSize {
+		validationErr.Add("size", fmt.Sprintf("must be between 1 and %v", MaxShopSize))
+	}
+	if s.PlanetModifier < 0 {
+		validationErr.Add("planetModifier", "must not be negative")
+	}
+
+	return validationErr.OrNil()
+}
+
+// ShopItem is a catalog item stocked by a shop at its local rarity and price
+type ShopItem struct {
+	Kind           ItemKind           `json:"kind" bson:"kind"`
+	ItemID         primitive.ObjectID `json:"itemId" bson:"itemId"`
+	Name           string             `json:"name" bson:"name"`
+	Rarity         int64              `json:"rarity" bson:"rarity"`
+	AdjustedRarity int64              `json:"adjustedRarity" bson:"adjustedRarity"`
+	Restricted     bool               `json:"restricted" bson:"restricted"`
+	Quantity       int64              `json:"quantity" bson:"quantity"`
+	Price          int64              `json:"price" bson:"price"`
+}
+
+// Shop is a generated store and its stock, it is kept until the GM regenerates it
+type Shop struct {
+	ID               primitive.ObjectID `json:"_id" bson:"_id"`
+	Name             string             `json:"name" bson:"name"`
+	Location         string             `json:"location" bson:"location"`
+	LocationModifier int64              `json:"locationModifier" bson:"locationModifier"`
+	Specialty        ShopSpecialty      `json:"specialty" bson:"specialty"`
+	PlanetModifier   float64            `json:"planetModifier" bson:"planetModifier"`
+	Size             int64              `json:"size" bson:"size"`
+	Seed             int64              `json:"seed" bson:"seed"`
+	GeneratedAt      time.Time          `json:"generatedAt" bson:"generatedAt"`
+	Items            []ShopItem         `json:"items" bson:"items"`
+}
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// GameLine is the FFG product line an item comes from
+type GameLine string
+
+// The game lines
+const (
+	EdgeOfTheEmpire GameLine = "EotE"
+	AgeOfRebellion  GameLine = "AoR"
+	ForceAndDestiny GameLine = "FaD"
+	Homebrew        GameLine = "Homebrew"
+)
+
+// GameLines lists every game line
+var GameLines = []GameLine{EdgeOfTheEmpire, AgeOfRebellion, ForceAndDestiny, Homebrew}
+
+// ParseGameLine returns the game line matching the given name, ignoring case
+func ParseGameLine(name string) (GameLine, error) {
+	for _, gameLine := range GameLines {
+		if strings.EqualFold(strings.TrimSpace(name), string(gameLine)) {
+			return gameLine, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not a game line, expected one of %v", name, GameLines)
+}
+
+// SourceBook is a book items are printed in
+type SourceBook struct {
+	Code     string   `json:"code"`
+	Title    string   `json:"title"`
+	GameLine GameLine `json:"gameLine"`
+}
+
+// SourceReference is where an item is printed, the page is optional
+type SourceReference struct {
+	Book string `json:"book" bson:"book"`
+	Page int64  `json:"page,omitempty" bson:"page,omitempty"`
+}
+
+// SourceRegistry holds the known source books keyed by their lower case code, it can be replaced with LoadSourceRegistry
+var SourceRegistry = registerSources([]SourceBook{
+	{Code: "EotE-CRB", Title: "Edge of the Empire Core Rulebook", GameLine: EdgeOfTheEmpire},
+	{Code: "EotE-DC", Title: "Dangerous Covenants", GameLine: EdgeOfTheEmpire},
+	{Code: "EotE-FC", Title: "Fly Casual", GameLine: EdgeOfTheEmpire},
+	{Code: "EotE-SM", Title: "Special Modifications", GameLine: EdgeOfTheEmpire},
+	{Code: "AoR-CRB", Title: "Age of Rebellion Core Rulebook", GameLine: AgeOfRebellion},
+	{Code: "AoR-FiB", Title: "Forged in Battle", GameLine: AgeOfRebellion},
+	{Code: "AoR-LbE", Title: "Lead by Example", GameLine: AgeOfRebellion},
+	{Code: "FaD-CRB", Title: "Force and Destiny Core Rulebook", GameLine: ForceAndDestiny},
+	{Code: "FaD-KtP", Title: "Keeping the Peace", GameLine: ForceAndDestiny},
+	{Code: "HB", Title: "Homebrew", GameLine: Homebrew},
+})
+
+// registerSources keys the source books by their lower case code
+func registerSources(books []SourceBook) map[string]SourceBook {
+	registry := map[string]SourceBook{}
+	for _, book := range books {
+		registry[strings.ToLower(book.Code)] = book
+	}
+	return registry
+}
+
+// LoadSourceRegistry replaces the source registry with the books listed in a JSON file
+func LoadSourceRegistry(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	books := []SourceBook{}
+	err = json.Unmarshal(data, &books)
+	if err != nil {
+		return fmt.Errorf("error reading source registry %v: %v", path, err)
+	}
+
+	validationErr := ValidationError{}
+	for i := range books {
+		field := fmt.Sprintf("[%d]", i)
+		if strings.TrimSpace(books[i].Code) == "" {
+			validationErr.Add(field+".code", "is required")
+		}
+		gameLine, err := ParseGameLine(string(books[i].GameLine))
+		if err != nil {
+			validationErr.Add(field+".gameLine", err.Error())
+		}
+		books[i].GameLine = gameLine
+	}
+	if len(validationErr) > 0 {
+		return fmt.Errorf("error reading source registry %v: %v", path, validationErr)
+	}
+
+	SourceRegistry = registerSources(books)
+	return nil
+}
+
+// LookupSource returns the registry entry for a book code, ignoring case
+func LookupSource(code string) (SourceBook, bool) {
+	book, ok := SourceRegistry[strings.ToLower(strings.TrimSpace(code))]
+	return book, ok
+}
+
+// SourceBooks returns every registered book ordered by code
+func SourceBooks() []SourceBook {
+	books := make([]SourceBook, 0, len(SourceRegistry))
+	for _, book := range SourceRegistry {
+		books = append(books, book)
+	}
+	sort.Slice(books, func(i, j int) bool { return books[i].Code < books[j].Code })
+	return books
+}
+
+// validateSources checks every source against the registry, canonicalizing book codes in place.
+// An item without a game line takes the game line of its first source.
+func validateSources(sources []SourceReference, gameLine *GameLine, validationErr *ValidationError) {
+	for i := range sources {
+		field := fmt.Sprintf("sources[%d]", i)
+		book, ok := LookupSource(sources[i].Book)
+		if !ok {
+			validationErr.Add(field+".book", fmt.Sprintf("unknown source book %v", sources[i].Book))
+			continue
+		}
+		sources[i].Book = book.Code
+
+		if sources[i].Page < 0 {
+			validationErr.Add(field+".page", "must not be negative")
+		}
+		if *gameLine == "" && i == 0 {
+			*gameLine = book.GameLine
+		}
+	}
+
+	if *gameLine == "" {
+		return
+	}
+	parsed, err := ParseGameLine(string(*gameLine))
+	if err != nil {
+		validationErr.Add("gameLine", err.Error())
+		return
+	}
+	*gameLine = parsed
+}
+
+// SourceCount is the number of items printed in a source book
+type SourceCount struct {
+	SourceBook
+	Weapons int64 `json:"weapons"`
+	Armor   int64 `json:"armor"`
+	Total   int64 `json:"total"`
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWeapon_Validate_Sources(t *testing.T) {
+	weapon := Weapon{Sources: []SourceReference{{Book: "eote-crb", Page: 154}, {Book: "EotE-DC"}}}
+
+	err := weapon.Validate()
+	if err != nil || weapon.Sources[0].Book != "EotE-CRB" || weapon.GameLine != EdgeOfTheEmpire {
+		t.Errorf("Validate() error:\ngot: %+v, %v\nexpected canonical book and the game line of the first source", weapon, err)
+	}
+
+	armor := Armor{Sources: []SourceReference{{Book: "AoR-CRB"}}, GameLine: "homebrew"}
+	err = armor.Validate()
+	if err != nil || armor.GameLine != Homebrew {
+		t.Errorf("Validate() error:\ngot: %+v, %v\nexpected the given game line to be kept", armor, err)
+	}
+}
+
+func TestWeapon_Validate_SourcesInvalid(t *testing.T) {
+	weapon := Weapon{Sources: []SourceReference{{Book: "Necronomicon"}, {Book: "EotE-CRB", Page: -1}}, GameLine: "Pathfinder"}
+
+	err := weapon.Validate()
+	validationErr, ok := err.(ValidationError)
+	if !ok || len(validationErr) != 3 {
+		t.Errorf("Validate() error:\ngot: %v\nexpected book, page and gameLine errors", err)
+	}
+}
+
+func TestLoadSourceRegistry(t *testing.T) {
+	original := SourceRegistry
+	defer func() { SourceRegistry = original }()
+
+	dir, err := ioutil.TempDir("", "sources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sources.json")
+	_ = ioutil.WriteFile(path, []byte(`[{"code": "GM-1", "title": "Table Rules", "gameLine": "homebrew"}]`), 0600)
+
+	err = LoadSourceRegistry(path)
+	book, ok := LookupSource("gm-1")
+	if err != nil || !ok || book.GameLine != Homebrew || len(SourceBooks()) != 1 {
+		t.Errorf("LoadSourceRegistry() error:\ngot: %+v, %v\nexpected the registry to hold GM-1 only", SourceBooks(), err)
+	}
+
+	_ = ioutil.WriteFile(path, []byte(`[{"title": "No Code", "gameLine": "Genesys"}]`), 0600)
+	if err = LoadSourceRegistry(path); err == nil {
+		t.Errorf("LoadSourceRegistry() error:\ngot: <nil>\nexpected code and gameLine errors")
+	}
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The v2 schema cleans up the v1 JSON without touching the stored documents: every field is camelCase, weapons spell
+// encumbrance correctly, armor is named by name, damage is always structured and the legacy special string is dropped
+// in favour of qualities. The To and From functions translate between the two so both versions share one collection.
+
+// DamageV2 is the v2 form of a weapon's damage, always the structured object rather than the printed notation
+type DamageV2 struct {
+	Base          int64 `json:"base"`
+	BrawnRelative bool  `json:"brawnRelative"`
+}
+
+// WeaponV2 is the v2 representation of a weapon
+type WeaponV2 struct {
+	ID          primitive.ObjectID    `json:"id"`
+	Name        string                `json:"name"`
+	Type        string                `json:"type"`
+	Skill       string                `json:"skill"`
+	Damage      DamageV2              `json:"damage"`
+	Critical    int64                 `json:"critical"`
+	Range       RangeBand             `json:"range"`
+	Encumbrance int64                 `json:"encumbrance"`
+	HardPoints  int64                 `json:"hardPoints"`
+	Price       int64                 `json:"price"`
+	Rarity      int64                 `json:"rarity"`
+	Restricted  bool                  `json:"restricted"`
+	Qualities   []Quality             `json:"qualities"`
+	Attachments []InstalledAttachment `json:"attachments"`
+	Sources     []SourceReference     `json:"sources,omitempty"`
+	GameLine    GameLine              `json:"gameLine,omitempty"`
+}
+
+// ArmorV2 is the v2 representation of an armor, the v1 type is its name
+type ArmorV2 struct {
+	ID          primitive.ObjectID    `json:"id"`
+	Name        string                `json:"name"`
+	Defense     int64                 `json:"defense"`
+	Soak        int64                 `json:"soak"`
+	Price       int64                 `json:"price"`
+	Encumbrance int64                 `json:"encumbrance"`
+	HardPoints  int64                 `json:"hardPoints"`
+	Rarity      int64                 `json:"rarity"`
+	Restricted  bool                  `json:"restricted"`
+	Qualities   []Quality             `json:"qualities"`
+	Attachments []InstalledAttachment `json:"attachments"`
+	Sources     []SourceReference     `json:"sources,omitempty"`
+	GameLine    GameLine              `json:"gameLine,omitempty"`
+}
+
+// WeaponV2Fields maps the v2 weapon fields that differ from the stored document to their stored names
+var WeaponV2Fields = map[string]string{
+	"id":          "_id",
+	"encumbrance": "encumberence",
+	"hardPoints":  "hp",
+}
+
+// ArmorV2Fields maps the v2 armor fields that differ from the stored document to their stored names
+var ArmorV2Fields = map[string]string{
+	"id":   "_id",
+	"name": "type",
+}
+
+// V2ValidationFields maps the v1 field names used in validation errors to their v2 names
+var V2ValidationFields = map[string]string{
+	"special": "qualities",
+	"hp":      "hardPoints",
+}
+
+// V2FieldNames maps every stored field name of the v2 fields back to its v2 name, along with the V2ValidationFields, so
+// validation, filter and sort errors can name the fields the way the v2 request did
+func V2FieldNames(fields map[string]string) map[string]string {
+	names := map[string]string{}
+	for v1, v2 := range V2ValidationFields {
+		names[v1] = v2
+	}
+	for v2, stored := range fields {
+		names[stored] = v2
+	}
+	return names
+}
+
+// ToWeaponV2 translates a stored weapon to its v2 representation
+func ToWeaponV2(w Weapon) WeaponV2 {
+	return WeaponV2{
+		ID:          w.ID,
+		Name:        w.Name,
+		Type:        w.WeaponType,
+		Skill:       w.Skill,
+		Damage:      DamageV2{Base: w.Damage.Base, BrawnRelative: w.Damage.BrawnRelative},
+		Critical:    w.Critical,
+		Range:       w.Range,
+		Encumbrance: w.Encumberence,
+		HardPoints:  w.HP,
+		Price:       w.Price,
+		Rarity:      w.Rarity,
+		Restricted:  w.Restricted,
+		Qualities:   qualitiesOrEmpty(w.Qualities),
+		Attachments: attachmentsOrEmpty(w.Attachments),
+		Sources:     w.Sources,
+		GameLine:    w.GameLine,
+	}
+}
+
+// FromWeaponV2 translates a v2 weapon to the stored weapon, the special string is rebuilt from the qualities on validation
+func FromWeaponV2(w WeaponV2) Weapon {
+	return Weapon{
+		ID:           w.ID,
+		WeaponType:   w.Type,
+		Name:         w.Name,
+		Skill:        w.Skill,
+		Damage:       Damage{Base: w.Damage.Base, BrawnRelative: w.Damage.BrawnRelative},
+		Critical:     w.Critical,
+		Range:        w.Range,
+		Encumberence: w.Encumbrance,
+		HP:           w.HardPoints,
+		Price:        w.Price,
+		Rarity:       w.Rarity,
+		Restricted:   w.Restricted,
+		Qualities:    w.Qualities,
+		Attachments:  w.Attachments,
+		Sources:      w.Sources,
+		GameLine:     w.GameLine,
+	}
+}
+
+// ToArmorV2 translates a stored armor to its v2 representation
+func ToArmorV2(a Armor) ArmorV2 {
+	return ArmorV2{
+		ID:          a.ID,
+		Name:        a.ArmorType,
+		Defense:     a.Defense,
+		Soak:        a.Soak,
+		Price:       a.Price,
+		Encumbrance: a.Encumbrance,
+		HardPoints:  a.HardPoints,
+		Rarity:      a.Rarity,
+		Restricted:  a.Restricted,
+		Qualities:   qualitiesOrEmpty(a.Qualities),
+		Attachments: attachmentsOrEmpty(a.Attachments),
+		Sources:     a.Sources,
+		GameLine:    a.GameLine,
+	}
+}
+
+// FromArmorV2 translates a v2 armor to the stored armor, the special string is rebuilt from the qualities on validation
+func FromArmorV2(a ArmorV2) Armor {
+	return Armor{
+		ID:          a.ID,
+		ArmorType:   a.Name,
+		Defense:     a.Defense,
+		Soak:        a.Soak,
+		Price:       a.Price,
+		Encumbrance: a.Encumbrance,
+		HardPoints:  a.HardPoints,
+		Rarity:      a.Rarity,
+		Restricted:  a.Restricted,
+		Qualities:   a.Qualities,
+		Attachments: a.Attachments,
+		Sources:     a.Sources,
+		GameLine:    a.GameLine,
+	}
+}
+
+func qualitiesOrEmpty(qualities []Quality) []Quality {
+	if qualities == nil {
+		return []Quality{}
+	}
+	return qualities
+}
+
+func attachmentsOrEmpty(attachments []InstalledAttachment) []InstalledAttachment {
+	if attachments == nil {
+		return []InstalledAttachment{}
+	}
+	return attachments
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWeaponV2_RoundTrip(t *testing.T) {
+	weapon := Weapon{
+		ID:           primitive.NewObjectID(),
+		WeaponType:   "Pistol",
+		Name:         "DL-44",
+		Damage:       Damage{Base: 7},
+		Critical:     3,
+		Range:        Medium,
+		Encumberence: 1,
+		HP:           3,
+		Qualities:    []Quality{{Name: "Stun Setting", Active: true}},
+		Attachments:  []InstalledAttachment{},
+	}
+
+	if translated := FromWeaponV2(ToWeaponV2(weapon)); !reflect.DeepEqual(translated, weapon) {
+		t.Errorf("FromWeaponV2() error:\ngot: %+v\nexpected: %+v", translated, weapon)
+	}
+
+	body, _ := json.Marshal(ToWeaponV2(weapon))
+	if !strings.Contains(string(body), `"encumbrance":1`) || !strings.Contains(string(body), `"hardPoints":3`) || !strings.Contains(string(body), `"damage":{"base":7,"brawnRelative":false}`) || strings.Contains(string(body), "_id") {
+		t.Errorf("ToWeaponV2() error:\ngot: %s\nexpected the v2 field names", body)
+	}
+}
+
+func TestArmorV2_RoundTrip(t *testing.T) {
+	armor := Armor{ID: primitive.NewObjectID(), ArmorType: "Padded Armor", Soak: 2, Encumbrance: 2, Qualities: []Quality{}, Attachments: []InstalledAttachment{}}
+
+	if translated := FromArmorV2(ToArmorV2(armor)); !reflect.DeepEqual(translated, armor) {
+		t.Errorf("FromArmorV2() error:\ngot: %+v\nexpected: %+v", translated, armor)
+	}
+	if ToArmorV2(armor).Name != "Padded Armor" {
+		t.Errorf("ToArmorV2() error:\ngot: %+v\nexpected the armor type as the name", ToArmorV2(armor))
+	}
+}
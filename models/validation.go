@@ -0,0 +1,74 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldError describes a single invalid field on a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a model or query fails validation, it lists every invalid field
+type ValidationError []FieldError
+
+//Error joins every field error into a single message
+func (v ValidationError) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, fieldErr := range v {
+		messages = append(messages, fieldErr.Field+": "+fieldErr.Message)
+	}
+
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+//Add appends a field error to the validation error
+func (v *ValidationError) Add(field, message string) {
+	*v = append(*v, FieldError{Field: field, Message: message})
+}
+
+//OrNil returns nil when there are no field errors so the result can be returned as an error
+func (v ValidationError) OrNil() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+//Merge appends the field errors of another validation error, any other error is recorded against the given field
+func (v *ValidationError) Merge(field string, err error) {
+	if err == nil {
+		return
+	}
+	if other, ok := err.(ValidationError); ok {
+		*v = append(*v, other...)
+		return
+	}
+	v.Add(field, err.Error())
+}
+
+// prefixFields nests the fields of a validation error under the given parent field
+func prefixFields(parent string, err error) error {
+	validationErr, ok := err.(ValidationError)
+	if !ok {
+		return err
+	}
+
+	prefixed := ValidationError{}
+	for _, fieldErr := range validationErr {
+		prefixed.Add(parent+"."+fieldErr.Field, fieldErr.Message)
+	}
+	return prefixed
+}
+
+// sortedKeys returns the keys of a field map in order so validation errors are reported consistently
+func sortedKeys(fields map[string]int64) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
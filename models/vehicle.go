@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FireArc is the arc a vehicle mounted weapon can fire into
+type FireArc string
+
+// The vehicle fire arcs
+const (
+	ForeArc      FireArc = "Fore"
+	AftArc       FireArc = "Aft"
+	PortArc      FireArc = "Port"
+	StarboardArc FireArc = "Starboard"
+	AllArcs      FireArc = "All"
+)
+
+// FireArcs lists every fire arc a vehicle weapon can be mounted in
+var FireArcs = []FireArc{ForeArc, AftArc, PortArc, StarboardArc, AllArcs}
+
+// ParseFireArc returns the fire arc matching the given name, ignoring case
+func ParseFireArc(name string) (FireArc, error) {
+	for _, arc := range FireArcs {
+		if strings.EqualFold(strings.TrimSpace(name), string(arc)) {
+			return arc, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not a fire arc, expected one of %v", name, FireArcs)
+}
+
+// DefenseArcs holds a vehicle's defense in each of its four zones
+type DefenseArcs struct {
+	Fore      int64 `json:"fore" bson:"fore"`
+	Aft       int64 `json:"aft" bson:"aft"`
+	Port      int64 `json:"port" bson:"port"`
+	Starboard int64 `json:"starboard" bson:"starboard"`
+}
+
+// VehicleWeapon is a reference to a catalog weapon mounted on a vehicle
+type VehicleWeapon struct {
+	WeaponID primitive.ObjectID `json:"weaponId" bson:"weaponId"`
+	FireArc  FireArc            `json:"fireArc" bson:"fireArc"`
+	Count    int64              `json:"count" bson:"count"`
+	Weapon   *Weapon            `json:"weapon,omitempty" bson:"-"`
+}
+
+// Vehicle struct is used to create a vehicle or starship object
+type Vehicle struct {
+	ID               primitive.ObjectID `json:"_id" bson:"_id"`
+	VehicleType      string             `json:"type" bson:"type"`
+	Name             string             `json:"name" bson:"name"`
+	Silhouette       int64              `json:"silhouette" bson:"silhouette"`
+	Speed            int64              `json:"speed" bson:"speed"`
+	Handling         int64              `json:"handling" bson:"handling"`
+	Armor            int64              `json:"armor" bson:"armor"`
+	HullTrauma       int64              `json:"hullTrauma" bson:"hullTrauma"`
+	SystemStrain     int64              `json:"systemStrain" bson:"systemStrain"`
+	Defense          DefenseArcs        `json:"defense" bson:"defense"`
+	HardPoints       int64              `json:"hardPoints" bson:"hardPoints"`
+	Crew             int64              `json:"crew" bson:"crew"`
+	Passengers       int64              `json:"passengers" bson:"passengers"`
+	CargoEncumbrance int64              `json:"cargoEncumbrance" bson:"cargoEncumbrance"`
+	Price            int64              `json:"price" bson:"price"`
+	Rarity           int64              `json:"rarity" bson:"rarity"`
+	Restricted       bool               `json:"restricted" bson:"restricted"`
+	Weapons          []VehicleWeapon    `json:"weapons" bson:"weapons"`
+}
+
+// Validate checks the vehicle before it is written to the database
+func (v *Vehicle) Validate() error {
+	validationErr := ValidationError{}
+
+	if strings.TrimSpace(v.Name) == "" {
+		validationErr.Add("name", "is required")
+	}
+	if v.Silhouette < 0 || v.Silhouette > 10 {
+		validationErr.Add("silhouette", "must be between 0 and 10")
+	}
+	if v.Speed < 0 || v.Speed > 6 {
+		validationErr.Add("speed", "must be between 0 and 6")
+	}
+
+	nonNegative := map[string]int64{
+		"armor":             v.Armor,
+		"hullTrauma":        v.HullTrauma,
+		"systemStrain":      v.SystemStrain,
+		"hardPoints":        v.HardPoints,
+		"crew":              v.Crew,
+		"passengers":        v.Passengers,
+		"cargoEncumbrance":  v.CargoEncumbrance,
+		"price":             v.Price,
+		"defense.fore":      v.Defense.Fore,
+		"defense.aft":       v.Defense.Aft,
+		"defense.port":      v.Defense.Port,
+		"defense.starboard": v.Defense.Starboard,
+	}
+	for _, field := range sortedKeys(nonNegative) {
+		if nonNegative[field] < 0 {
+			validationErr.Add(field, "must not be negative")
+		}
+	}
+	validateRarity(v.Rarity, &validationErr)
+
+	for i := range v.Weapons {
+		field := fmt.Sprintf("weapons[%d]", i)
+		if v.Weapons[i].WeaponID.IsZero() {
+			validationErr.Add(field+".weaponId", "is required")
+		}
+		if v.Weapons[i].Count < 1 {
+			validationErr.Add(field+".count", "must be at least 1")
+		}
+		arc, err := ParseFireArc(string(v.Weapons[i].FireArc))
+		if err != nil {
+			validationErr.Add(field+".fireArc", err.Error())
+			continue
+		}
+		v.Weapons[i].FireArc = arc
+		v.Weapons[i].Weapon = nil
+	}
+
+	return validationErr.OrNil()
+}
+
+// UnmarshalJSON accepts the printed rarity notation, "7 (R)" also marks the vehicle as restricted
+func (v *Vehicle) UnmarshalJSON(data []byte) error {
+	type vehicle Vehicle
+	return decodeRarityJSON(data, (*vehicle)(v), &v.Rarity, &v.Restricted)
+}
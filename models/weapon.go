@@ -4,16 +4,63 @@ import "go.mongodb.org/mongo-driver/bson/primitive"
 
 // Weapon struct is used to create a Weapon object
 type Weapon struct {
-	ID           primitive.ObjectID `json:"_id" bson:"_id"`
-	WeaponType   string             `json:"type" bson:"type"`
-	Name         string             `json:"name" bson:"name"`
-	Skill        string             `json:"skill" bson:"skill"`
-	Damage       string             `json:"damage" bson:"damage"`
-	Critical     int64              `json:"critical" bson:"critical"`
-	Range        string             `json:"range" bson:"range"`
-	Encumberence int64              `json:"encumberence" bson:"encumberence"`
-	HP           int64              `json:"hp" bson:"hp"`
-	Price        int64              `json:"price" bson:"price"`
-	Rarity       int64              `json:"rarity" bson:"rarity"`
-	Special      string             `json:"special" bson:"special"`
+	ID           primitive.ObjectID    `json:"_id" bson:"_id"`
+	WeaponType   string                `json:"type" bson:"type"`
+	Name         string                `json:"name" bson:"name"`
+	Skill        string                `json:"skill" bson:"skill"`
+	Damage       Damage                `json:"damage" bson:"damage"`
+	Critical     int64                 `json:"critical" bson:"critical"`
+	Range        RangeBand             `json:"range" bson:"range"`
+	Encumberence int64                 `json:"encumberence" bson:"encumberence"`
+	HP           int64                 `json:"hp" bson:"hp"`
+	Price        int64                 `json:"price" bson:"price"`
+	Rarity       int64                 `json:"rarity" bson:"rarity"`
+	Restricted   bool                  `json:"restricted" bson:"restricted"`
+	Special      string                `json:"special" bson:"special"`
+	Qualities    []Quality             `json:"qualities" bson:"qualities"`
+	Attachments  []InstalledAttachment `json:"attachments" bson:"attachments,omitempty"`
+	Sources      []SourceReference     `json:"sources,omitempty" bson:"sources,omitempty"`
+	GameLine     GameLine              `json:"gameLine,omitempty" bson:"gameLine,omitempty"`
+}
+
+// NormalizeQualities keeps Special and Qualities in step, documents written before qualities existed are parsed from Special
+func (w *Weapon) NormalizeQualities() error {
+	return normalizeQualities(&w.Special, &w.Qualities)
+}
+
+// EffectiveDamage returns the weapon's damage when wielded by a character with the given Brawn
+func (w *Weapon) EffectiveDamage(brawn int64) int64 {
+	return w.Damage.Effective(brawn)
+}
+
+// Validate checks the weapon before it is written to the database
+func (w *Weapon) Validate() error {
+	validationErr := ValidationError{}
+
+	validationErr.Merge("special", w.NormalizeQualities())
+
+	if w.Range != "" {
+		band, err := ParseRangeBand(string(w.Range))
+		if err != nil {
+			validationErr.Add("range", err.Error())
+		} else {
+			w.Range = band
+		}
+	}
+
+	validateRarity(w.Rarity, &validationErr)
+	validateHardPoints(w.HP, w.Attachments, &validationErr)
+	validateSources(w.Sources, &w.GameLine, &validationErr)
+
+	if w.Damage.Base < 0 {
+		validationErr.Add("damage", "must not be negative")
+	}
+
+	return validationErr.OrNil()
+}
+
+// UnmarshalJSON accepts the printed rarity notation, "7 (R)" also marks the weapon as restricted
+func (w *Weapon) UnmarshalJSON(data []byte) error {
+	type weapon Weapon
+	return decodeRarityJSON(data, (*weapon)(w), &w.Rarity, &w.Restricted)
 }
@@ -6,9 +6,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
+	model "github.com/geeksheik9/gear-CRUD/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
@@ -74,12 +76,17 @@ func StringToObjectID(ID string) (primitive.ObjectID, error) {
 	return objID, nil
 }
 
+// ErrConflict is wrapped by errors caused by another request changing the same document first, CheckError returns 409 for it
+var ErrConflict = errors.New("the document was changed by another request")
+
 // CheckError checks the err message and returns a code based on the message.
 func CheckError(err error) int {
 	var code int
 	// TODO: Check error returns and make this into a switch statement.
 	if err == nil {
 		code = http.StatusOK
+	} else if errors.Is(err, ErrConflict) {
+		code = http.StatusConflict
 	} else if strings.Contains(err.Error(), "no documents in result") ||
 		strings.Contains(err.Error(), "out of bounds") ||
 		strings.Contains(err.Error(), "not found") {
@@ -88,7 +95,8 @@ func CheckError(err error) int {
 		strings.Contains(err.Error(), "E11001 duplicate key error") {
 		code = http.StatusConflict
 	} else if strings.Contains(err.Error(), "E10334") ||
-		strings.Contains(err.Error(), "Invalid request payload, unable to marshal into json, err: ") {
+		strings.Contains(err.Error(), "Invalid request payload, unable to marshal into json, err: ") ||
+		strings.Contains(err.Error(), "validation failed") {
 		code = http.StatusBadRequest
 	} else {
 		code = http.StatusInternalServerError
@@ -122,34 +130,61 @@ func BuildQuery(ID *primitive.ObjectID, name *string, other ...bson.M) bson.M {
 	return c
 }
 
-//BuildFilter sets up the mongo filtering
-func BuildFilter(queryParams url.Values) (int, int, string, bson.M) {
-	filter := bson.M{}
+//BuildFilter sets up the mongo filtering, every other query parameter is a field[op]=value filter on one of the given fields.
+//Values are converted to the type of the field and unknown fields or operators are returned as a validation error.
+func BuildFilter(queryParams url.Values, fields Fields) (int, int, string, bson.M, error) {
+	params := make([]string, 0, len(queryParams))
+	for queryParam := range queryParams {
+		params = append(params, queryParam)
+	}
+	sort.Strings(params)
+
 	filters := []bson.M{}
+	validationErr := model.ValidationError{}
 	pageNumber := 0
 	pageCount := 10000
-	sort := "priority"
-	if len(queryParams) > 0 {
-		for queryParam, paramValue := range queryParams {
-			switch queryParam {
-			case "pageNumber":
-				pageNumber, _ = strconv.Atoi(paramValue[0])
-			case "pageCount":
-				pageCount, _ = strconv.Atoi(paramValue[0])
-			case "sort":
-				sort = paramValue[0]
-			default:
-				m := bson.M{queryParam: paramValue[0]}
+	sort := IDField
+
+	for _, queryParam := range params {
+		paramValue := queryParams[queryParam]
+		switch queryParam {
+		case "pageNumber":
+			pageNumber, _ = strconv.Atoi(paramValue[0])
+		case "pageCount":
+			pageCount, _ = strconv.Atoi(paramValue[0])
+		case "sort":
+			sort = paramValue[0]
+		default:
+			if m := fieldFilter(fields, queryParam, paramValue[0], &validationErr); m != nil {
 				filters = append(filters, m)
 			}
 		}
-		if len(filters) > 0 {
-			filter = bson.M{"$and": filters}
-		} else {
-			filter = nil
+	}
+
+	if len(validationErr) > 0 {
+		return 0, 0, "", nil, validationErr
+	}
+	if len(filters) == 0 {
+		return pageNumber, pageCount, sort, nil, nil
+	}
+	return pageNumber, pageCount, sort, bson.M{"$and": filters}, nil
+}
+
+//MergeFilters combines mongo filters into a single $and filter, empty filters are skipped
+func MergeFilters(filters ...bson.M) bson.M {
+	conditions := []bson.M{}
+	for _, filter := range filters {
+		if len(filter) > 0 {
+			conditions = append(conditions, filter)
 		}
-		return pageNumber, pageCount, sort, filter
 	}
 
-	return pageNumber, pageCount, sort, nil
+	switch len(conditions) {
+	case 0:
+		return bson.M{}
+	case 1:
+		return conditions[0]
+	default:
+		return bson.M{"$and": conditions}
+	}
 }
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func Test_RespondWithJson(t *testing.T) {
@@ -106,10 +109,32 @@ func TestCheckError(t *testing.T) {
 	if code := CheckError(errors.New("E11000 duplicate key error")); code != http.StatusConflict {
 		t.Errorf("TestCheckError(),\n   expected: %v\n   got:      %v", http.StatusConflict, code)
 	}
+	if code := CheckError(fmt.Errorf("attachments: %w", ErrConflict)); code != http.StatusConflict {
+		t.Errorf("TestCheckError(),\n   expected: %v\n   got:      %v", http.StatusConflict, code)
+	}
 	if code := CheckError(errors.New("E10334")); code != http.StatusBadRequest {
 		t.Errorf("TestCheckError(),\n   expected: %v\n   got:      %v", http.StatusBadRequest, code)
 	}
+	if code := CheckError(errors.New("validation failed: range: invalid")); code != http.StatusBadRequest {
+		t.Errorf("TestCheckError(),\n   expected: %v\n   got:      %v", http.StatusBadRequest, code)
+	}
 	if code := CheckError(errors.New("E1")); code != http.StatusInternalServerError {
 		t.Errorf("TestCheckError(),\n   expected: %v\n   got:      %v", http.StatusInternalServerError, code)
 	}
 }
+
+func TestMergeFilters(t *testing.T) {
+	if filter := MergeFilters(nil, bson.M{}); len(filter) != 0 {
+		t.Errorf("MergeFilters() error:\n   expected: empty filter\n   got:      %v", filter)
+	}
+
+	single := bson.M{"name": "test"}
+	if filter := MergeFilters(nil, single); !reflect.DeepEqual(filter, single) {
+		t.Errorf("MergeFilters() error:\n   expected: %v\n   got:      %v", single, filter)
+	}
+
+	expected := bson.M{"$and": []bson.M{single, {"price": 5}}}
+	if filter := MergeFilters(single, bson.M{"price": 5}); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("MergeFilters() error:\n   expected: %v\n   got:      %v", expected, filter)
+	}
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fields maps the filterable fields of a stored model, in dotted bson notation, to their Go types
+type Fields map[string]reflect.Type
+
+var (
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// ModelFields reads the bson field names of a model, nested documents and arrays of documents are listed with dotted names
+// such as "damage.base" or "qualities.name". Fields that are not stored are skipped.
+func ModelFields(document interface{}) Fields {
+	fields := Fields{}
+	addFields(fields, "", reflect.TypeOf(document), map[reflect.Type]bool{})
+	return fields
+}
+
+func addFields(fields Fields, prefix string, t reflect.Type, visiting map[reflect.Type]bool) {
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("bson"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+		inline := false
+		for _, option := range tag[1:] {
+			inline = inline || option == "inline"
+		}
+
+		fieldType := elementType(field.Type)
+		if inline && fieldType.Kind() == reflect.Struct {
+			addFields(fields, prefix, fieldType, visiting)
+			continue
+		}
+
+		name := tag[0]
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		name = prefix + name
+
+		if fieldType.Kind() == reflect.Struct && fieldType != objectIDType && fieldType != timeType {
+			addFields(fields, name+".", fieldType, visiting)
+			continue
+		}
+		fields[name] = fieldType
+	}
+}
+
+// elementType returns the type a filter value is compared against, the element of pointers and arrays
+func elementType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || (t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8) {
+		t = t.Elem()
+	}
+	return t
+}
+
+// SplitOperator splits a query parameter such as "price[lte]" into its field and operator
+func SplitOperator(queryParam string) (string, string) {
+	open := strings.Index(queryParam, "[")
+	if open < 1 || !strings.HasSuffix(queryParam, "]") {
+		return queryParam, ""
+	}
+	return queryParam[:open], queryParam[open+1 : len(queryParam)-1]
+}
+
+// MaxRegexLength caps the length of a regex filter value
+const MaxRegexLength = 64
+
+// FilterOperators maps the operators accepted in field[op] query parameters to their mongo operators
+var FilterOperators = map[string]string{
+	"eq":     "$eq",
+	"ne":     "$ne",
+	"gt":     "$gt",
+	"gte":    "$gte",
+	"lt":     "$lt",
+	"lte":    "$lte",
+	"in":     "$in",
+	"nin":    "$nin",
+	"regex":  "$regex",
+	"exists": "$exists",
+}
+
+// fieldFilter builds the mongo condition for a single field[op]=value query parameter, coercing the value to the field's type
+func fieldFilter(fields Fields, queryParam, value string, validationErr *model.ValidationError) bson.M {
+	field, operator := SplitOperator(queryParam)
+	fieldType, ok := fields[field]
+	if !ok {
+		validationErr.Add(queryParam, fmt.Sprintf("unknown field %v", field))
+		return nil
+	}
+
+	if operator == "" {
+		operator = "eq"
+	}
+	mongoOperator, ok := FilterOperators[operator]
+	if !ok {
+		validationErr.Add(queryParam, fmt.Sprintf("unsupported operator %v", operator))
+		return nil
+	}
+
+	switch operator {
+	case "exists":
+		exists, err := strconv.ParseBool(value)
+		if err != nil {
+			validationErr.Add(queryParam, fmt.Sprintf("%v is not true or false", value))
+			return nil
+		}
+		return bson.M{field: bson.M{mongoOperator: exists}}
+	case "regex":
+		if fieldType.Kind() != reflect.String {
+			validationErr.Add(queryParam, fmt.Sprintf("%v is not a text field", field))
+			return nil
+		}
+		// only literal prefixes such as ^Blaster are matched, patterns that can backtrack are never handed to mongo
+		if len(value) > MaxRegexLength {
+			validationErr.Add(queryParam, fmt.Sprintf("must be at most %v characters", MaxRegexLength))
+			return nil
+		}
+		prefix := strings.TrimPrefix(value, "^")
+		if prefix == value || prefix == "" || regexp.QuoteMeta(prefix) != prefix {
+			validationErr.Add(queryParam, fmt.Sprintf("%v is not an anchored prefix such as ^Blaster", value))
+			return nil
+		}
+		return bson.M{field: bson.M{mongoOperator: value}}
+	case "in", "nin":
+		values := []interface{}{}
+		for _, item := range strings.Split(value, ",") {
+			coerced, err := coerce(fieldType, strings.TrimSpace(item))
+			if err != nil {
+				validationErr.Add(queryParam, err.Error())
+				return nil
+			}
+			values = append(values, coerced)
+		}
+		return bson.M{field: bson.M{mongoOperator: values}}
+	}
+
+	coerced, err := coerce(fieldType, value)
+	if err != nil {
+		validationErr.Add(queryParam, err.Error())
+		return nil
+	}
+	if operator == "eq" {
+		return bson.M{field: coerced}
+	}
+	return bson.M{field: bson.M{mongoOperator: coerced}}
+}
+
+// coerce converts a query parameter value to the Go type of the field so it matches the stored BSON type
+func coerce(t reflect.Type, value string) (interface{}, error) {
+	switch {
+	case t == objectIDType:
+		id, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			return nil, fmt.Errorf("%v is not a valid id", value)
+		}
+		return id, nil
+	case t == timeType:
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%v is not an RFC 3339 time", value)
+		}
+		return parsed, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return value, nil
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%v is not true or false", value)
+		}
+		return parsed, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v is not a whole number", value)
+		}
+		return parsed, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v is not a positive whole number", value)
+		}
+		return int64(parsed), nil
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v is not a number", value)
+		}
+		return parsed, nil
+	}
+
+	return nil, fmt.Errorf("%v fields cannot be filtered", t)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestModelFields(t *testing.T) {
+	fields := ModelFields(model.Weapon{})
+
+	expected := map[string]reflect.Type{
+		"_id":                    reflect.TypeOf(primitive.ObjectID{}),
+		"price":                  reflect.TypeOf(int64(0)),
+		"range":                  reflect.TypeOf(model.RangeBand("")),
+		"damage.base":            reflect.TypeOf(int64(0)),
+		"damage.brawnRelative":   reflect.TypeOf(false),
+		"qualities.name":         reflect.TypeOf(""),
+		"attachments.hardPoints": reflect.TypeOf(int64(0)),
+	}
+	for name, fieldType := range expected {
+		if fields[name] != fieldType {
+			t.Errorf("ModelFields() error:\n   expected: %v to be %v\n   got:      %v", name, fieldType, fields[name])
+		}
+	}
+	if _, ok := fields["damage"]; ok {
+		t.Errorf("ModelFields() error:\n   expected: nested documents to be listed by their fields\n   got:      %v", fields)
+	}
+}
+
+func TestBuildFilter_Operators(t *testing.T) {
+	query := url.Values{
+		"price[lte]":      {"1000"},
+		"rarity[in]":      {"1, 2,3"},
+		"name[regex]":     {"^Blaster"},
+		"special[exists]": {"true"},
+		"restricted":      {"false"},
+		"sort":            {"price"},
+		"pageNumber":      {"2"},
+		"pageCount":       {"5"},
+	}
+
+	pageNumber, pageCount, sort, filter, err := BuildFilter(query, ModelFields(model.Weapon{}))
+	if err != nil || pageNumber != 2 || pageCount != 5 || sort != "price" {
+		t.Fatalf("BuildFilter() error:\n   expected: page 2 of 5 sorted by price\n   got:      %v %v %v %v", pageNumber, pageCount, sort, err)
+	}
+
+	expected := bson.M{"$and": []bson.M{
+		{"name": bson.M{"$regex": "^Blaster"}},
+		{"price": bson.M{"$lte": int64(1000)}},
+		{"rarity": bson.M{"$in": []interface{}{int64(1), int64(2), int64(3)}}},
+		{"restricted": false},
+		{"special": bson.M{"$exists": true}},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("BuildFilter() error:\n   expected: %v\n   got:      %v", expected, filter)
+	}
+}
+
+func TestBuildFilter_Invalid(t *testing.T) {
+	query := url.Values{
+		"colour":          {"red"},
+		"price":           {"cheap"},
+		"price[between]":  {"1"},
+		"rarity[regex]":   {"^1"},
+		"type[regex]":     {"("},
+		"special[exists]": {"maybe"},
+	}
+
+	_, _, _, filter, err := BuildFilter(query, ModelFields(model.Armor{}))
+	validationErr, ok := err.(model.ValidationError)
+	if !ok || len(validationErr) != 6 || filter != nil {
+		t.Errorf("BuildFilter() error:\n   expected: 6 field errors\n   got:      %v", err)
+	}
+}
+
+func TestBuildFilter_RegexPrefixOnly(t *testing.T) {
+	for _, pattern := range []string{"Blaster", "^", "^(a+)+$", "^Blaster.*", "^" + strings.Repeat("a", MaxRegexLength)} {
+		_, _, _, filter, err := BuildFilter(url.Values{"name[regex]": {pattern}}, ModelFields(model.Weapon{}))
+		if _, ok := err.(model.ValidationError); !ok || filter != nil {
+			t.Errorf("BuildFilter(%v) error:\n   expected: a name[regex] field error\n   got:      %v", pattern, err)
+		}
+	}
+}
+
+func TestBuildFilter_Empty(t *testing.T) {
+	pageNumber, pageCount, sort, filter, err := BuildFilter(url.Values{}, ModelFields(model.Gear{}))
+	if err != nil || filter != nil || pageNumber != 0 || pageCount != 10000 || sort != "_id" {
+		t.Errorf("BuildFilter() error:\n   expected: the default paging and no filter\n   got:      %v %v %v %v %v", pageNumber, pageCount, sort, filter, err)
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+)
+
+// The paging query parameters, a cursor replaces the page number when reading a page after a nextCursor
+const (
+	PageNumberParam = "pageNumber"
+	PageCountParam  = "pageCount"
+	CursorParam     = "cursor"
+)
+
+// DefaultMaxPageSize is the largest page served when no maximum is configured
+const DefaultMaxPageSize = 100
+
+// ParsePage reads the pageNumber and pageCount query parameters, pages are numbered from 1 and pageCount defaults to the
+// maximum page size. The returned query has both parameters set so the database applies the same paging.
+func ParsePage(queryParams url.Values, maxPageSize int) (url.Values, int, int, error) {
+	if maxPageSize < 1 {
+		maxPageSize = DefaultMaxPageSize
+	}
+	validationErr := model.ValidationError{}
+
+	pageNumber := 1
+	if value := queryParams.Get(PageNumberParam); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			validationErr.Add(PageNumberParam, fmt.Sprintf("%v is not a page number", value))
+		}
+		if parsed > 1 {
+			pageNumber = parsed
+		}
+	}
+
+	pageCount := maxPageSize
+	if value := queryParams.Get(PageCountParam); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPageSize {
+			validationErr.Add(PageCountParam, fmt.Sprintf("must be a number between 1 and %v", maxPageSize))
+		}
+		pageCount = parsed
+	}
+
+	if len(validationErr) > 0 {
+		return nil, 0, 0, validationErr
+	}
+
+	paged := url.Values{}
+	for key, values := range queryParams {
+		paged[key] = values
+	}
+	paged.Set(PageNumberParam, strconv.Itoa(pageNumber))
+	paged.Set(PageCountParam, strconv.Itoa(pageCount))
+	return paged, pageNumber, pageCount, nil
+}
+
+// LinkHeader builds the RFC 8288 Link header for the page, linking the first, previous, next and last pages of the request URL.
+// Pages read from a cursor only link the first page and, through the next cursor, the next page.
+func LinkHeader(requestURL *url.URL, page model.Page) string {
+	link := func(rel string, set func(query url.Values)) string {
+		query := requestURL.Query()
+		query.Del(CursorParam)
+		query.Set(PageCountParam, strconv.Itoa(page.PageSize))
+		set(query)
+		target := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%v>; rel="%v"`, target.String(), rel)
+	}
+	pageLink := func(pageNumber int, rel string) string {
+		return link(rel, func(query url.Values) { query.Set(PageNumberParam, strconv.Itoa(pageNumber)) })
+	}
+
+	links := []string{pageLink(1, "first")}
+	if requestURL.Query().Get(CursorParam) != "" {
+		if page.NextCursor != "" {
+			links = append(links, link("next", func(query url.Values) {
+				query.Del(PageNumberParam)
+				query.Set(CursorParam, page.NextCursor)
+			}))
+		}
+		return strings.Join(links, ", ")
+	}
+
+	if page.PageNumber > 1 {
+		links = append(links, pageLink(page.PageNumber-1, "prev"))
+	}
+	if page.HasNext {
+		links = append(links, pageLink(page.PageNumber+1, "next"))
+	}
+	links = append(links, pageLink(page.TotalPages, "last"))
+
+	return strings.Join(links, ", ")
+}
+
+// RespondWithPage writes the page as JSON with its Link header
+func RespondWithPage(w http.ResponseWriter, requestURL *url.URL, page model.Page) {
+	if w != nil {
+		w.Header().Set("Link", LinkHeader(requestURL, page))
+	}
+	RespondWithJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+)
+
+func TestParsePage_Defaults(t *testing.T) {
+	query, pageNumber, pageCount, err := ParsePage(url.Values{"name": {"Blaster"}}, 0)
+	if err != nil {
+		t.Fatalf("ParsePage() error:\n   expected: <nil>\n   got:      %v", err)
+	}
+	if pageNumber != 1 || pageCount != DefaultMaxPageSize {
+		t.Errorf("ParsePage() error:\n   expected: page 1 of %v\n   got:      page %v of %v", DefaultMaxPageSize, pageNumber, pageCount)
+	}
+	if query.Get(PageNumberParam) != "1" || query.Get(PageCountParam) != "100" || query.Get("name") != "Blaster" {
+		t.Errorf("ParsePage() error:\n   expected: the query with the paging set\n   got:      %v", query)
+	}
+}
+
+func TestParsePage_Invalid(t *testing.T) {
+	tests := []url.Values{
+		{PageCountParam: {"51"}},
+		{PageCountParam: {"0"}},
+		{PageNumberParam: {"-1"}},
+		{PageNumberParam: {"first"}},
+	}
+
+	for _, test := range tests {
+		_, _, _, err := ParsePage(test, 50)
+		var validationErr model.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("ParsePage(%v) error:\n   expected: a validation error\n   got:      %v", test, err)
+		}
+	}
+}
+
+func TestLinkHeader(t *testing.T) {
+	requestURL, _ := url.Parse("/weapon?skill=Ranged&pageCount=10")
+
+	link := LinkHeader(requestURL, model.NewPage(nil, 5, 1, 10))
+	expected := `</weapon?pageCount=10&pageNumber=1&skill=Ranged>; rel="first", ` +
+		`</weapon?pageCount=10&pageNumber=1&skill=Ranged>; rel="last"`
+	if link != expected {
+		t.Errorf("LinkHeader() error:\n   expected: %v\n   got:      %v", expected, link)
+	}
+}
+
+func TestLinkHeader_Cursor(t *testing.T) {
+	requestURL, _ := url.Parse("/armor?cursor=abc&pageCount=10")
+
+	page := model.NewPage(nil, 25, 1, 10).WithCursor("", true)
+	if page.HasNext {
+		t.Errorf("WithCursor() error:\n   expected: no next page without a next cursor\n   got:      %+v", page)
+	}
+
+	link := LinkHeader(requestURL, page)
+	expected := `</armor?pageCount=10&pageNumber=1>; rel="first"`
+	if link != expected {
+		t.Errorf("LinkHeader() error:\n   expected: %v\n   got:      %v", expected, link)
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	tests := []struct {
+		total      int64
+		pageNumber int
+		hasNext    bool
+		lastPage   int
+	}{
+		{total: 0, pageNumber: 1, hasNext: false, lastPage: 1},
+		{total: 20, pageNumber: 1, hasNext: true, lastPage: 2},
+		{total: 20, pageNumber: 2, hasNext: false, lastPage: 2},
+		{total: 21, pageNumber: 2, hasNext: true, lastPage: 3},
+	}
+
+	for _, test := range tests {
+		page := model.NewPage(nil, test.total, test.pageNumber, 10)
+		if page.HasNext != test.hasNext || page.TotalPages != test.lastPage {
+			t.Errorf("NewPage(%v, %v) error:\n   expected: hasNext %v, last page %v\n   got:      hasNext %v, last page %v",
+				test.total, test.pageNumber, test.hasNext, test.lastPage, page.HasNext, page.TotalPages)
+		}
+	}
+}
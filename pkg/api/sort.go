@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SortParam is the query parameter listing the fields to sort on
+const SortParam = "sort"
+
+// IDField is the document id, every sort ends with it so the order is total
+const IDField = "_id"
+
+// SortField is one key of a sort, Field is the stored or computed field the documents are sorted on
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// Sort is an ordered list of sort keys
+type Sort []SortField
+
+// ParseSort reads a sort such as "-price,name", fields prefixed with - sort descending. Every field must be one of the
+// sortable fields, which map the name used in the query to the field sorted on. The id is added as the last key when it
+// is not sorted on already, so documents sharing every sort value still come back in the same order.
+func ParseSort(value string, sortable map[string]string) (Sort, error) {
+	validationErr := model.ValidationError{}
+	sort := Sort{}
+	sorted := map[string]bool{}
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		descending := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		field, ok := sortable[name]
+		if !ok {
+			validationErr.Add(SortParam, fmt.Sprintf("cannot sort by %v", name))
+			continue
+		}
+		if sorted[field] {
+			validationErr.Add(SortParam, fmt.Sprintf("%v is sorted on more than once", name))
+			continue
+		}
+		sorted[field] = true
+		sort = append(sort, SortField{Field: field, Descending: descending})
+	}
+
+	if len(validationErr) > 0 {
+		return nil, validationErr
+	}
+	if !sorted[IDField] {
+		sort = append(sort, SortField{Field: IDField})
+	}
+	return sort, nil
+}
+
+// Document returns the mongo sort document
+func (s Sort) Document() bson.D {
+	document := bson.D{}
+	for _, key := range s {
+		direction := 1
+		if key.Descending {
+			direction = -1
+		}
+		document = append(document, bson.E{Key: key.Field, Value: direction})
+	}
+	return document
+}
+
+// String returns the sort in the form of the sort parameter, naming the fields sorted on
+func (s Sort) String() string {
+	keys := make([]string, 0, len(s))
+	for _, key := range s {
+		if key.Descending {
+			keys = append(keys, "-"+key.Field)
+			continue
+		}
+		keys = append(keys, key.Field)
+	}
+	return strings.Join(keys, ",")
+}
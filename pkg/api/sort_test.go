@@ -0,0 +1,55 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var testSortFields = map[string]string{
+	"_id":    "_id",
+	"name":   "name",
+	"price":  "price",
+	"damage": "effectiveDamage",
+}
+
+func TestParseSort(t *testing.T) {
+	sort, err := ParseSort("-price, name,damage", testSortFields)
+	if err != nil {
+		t.Fatalf("ParseSort() error:\n   expected: <nil>\n   got:      %v", err)
+	}
+
+	expected := Sort{{Field: "price", Descending: true}, {Field: "name"}, {Field: "effectiveDamage"}, {Field: "_id"}}
+	if !reflect.DeepEqual(sort, expected) {
+		t.Errorf("ParseSort() error:\n   expected: %v\n   got:      %v", expected, sort)
+	}
+
+	document := bson.D{{Key: "price", Value: -1}, {Key: "name", Value: 1}, {Key: "effectiveDamage", Value: 1}, {Key: "_id", Value: 1}}
+	if !reflect.DeepEqual(sort.Document(), document) {
+		t.Errorf("Document() error:\n   expected: %v\n   got:      %v", document, sort.Document())
+	}
+	if sort.String() != "-price,name,effectiveDamage,_id" {
+		t.Errorf("String() error:\n   expected: -price,name,effectiveDamage,_id\n   got:      %v", sort.String())
+	}
+}
+
+func TestParseSort_ID(t *testing.T) {
+	sort, err := ParseSort("", testSortFields)
+	if err != nil || !reflect.DeepEqual(sort, Sort{{Field: "_id"}}) {
+		t.Errorf("ParseSort() error:\n   expected: the id only\n   got:      %v %v", sort, err)
+	}
+
+	sort, err = ParseSort("-_id", testSortFields)
+	if err != nil || !reflect.DeepEqual(sort, Sort{{Field: "_id", Descending: true}}) {
+		t.Errorf("ParseSort() error:\n   expected: the id descending only\n   got:      %v %v", sort, err)
+	}
+}
+
+func TestParseSort_Invalid(t *testing.T) {
+	for _, value := range []string{"priority", "price,-price", "name,special"} {
+		if _, err := ParseSort(value, testSortFields); err == nil {
+			t.Errorf("ParseSort(%v) error:\n   expected: a validation error\n   got:      <nil>", value)
+		}
+	}
+}
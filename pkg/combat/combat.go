@@ -0,0 +1,216 @@
+// Package combat resolves combat checks with catalog weapons using the narrative dice.
+package combat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/dice"
+)
+
+// The characteristics used by combat skills
+const (
+	Brawn   = "brawn"
+	Agility = "agility"
+)
+
+// DefaultDifficulty is the difficulty of a combat check when none is given, an Average check
+const DefaultDifficulty = int64(model.Average)
+
+// MaxPoolSize is the most dice of one type a check may roll, every die is rolled one at a time
+const MaxPoolSize = 20
+
+// SkillCharacteristics maps every combat skill, keyed by its normalized name, to the characteristic it is rolled with
+var SkillCharacteristics = map[string]string{
+	"brawl":          Brawn,
+	"melee":          Brawn,
+	"lightsaber":     Brawn,
+	"ranged (light)": Agility,
+	"ranged (heavy)": Agility,
+	"gunnery":        Agility,
+}
+
+// NormalizeSkill lower cases a skill name and accepts square brackets for the specialization, e.g. "Ranged [Light]"
+func NormalizeSkill(skill string) string {
+	normalized := strings.ToLower(strings.TrimSpace(skill))
+	normalized = strings.NewReplacer("[", "(", "]", ")").Replace(normalized)
+	return strings.Join(strings.Fields(normalized), " ")
+}
+
+// Attacker is the character making a combat check
+type Attacker struct {
+	Characteristics map[string]int64 `json:"characteristics"`
+	Skills          map[string]int64 `json:"skills"`
+}
+
+// Characteristic returns the rating of a characteristic, ignoring case
+func (a Attacker) Characteristic(name string) int64 {
+	for characteristic, rating := range a.Characteristics {
+		if strings.EqualFold(characteristic, name) {
+			return rating
+		}
+	}
+	return 0
+}
+
+// Ranks returns the ranks the attacker has in a skill, matching normalized names
+func (a Attacker) Ranks(skill string) int64 {
+	for name, ranks := range a.Skills {
+		if NormalizeSkill(name) == NormalizeSkill(skill) {
+			return ranks
+		}
+	}
+	return 0
+}
+
+// AttackRequest is the request body used to roll an attack with a weapon.
+// Difficulty defaults to an Average check, Seed makes the roll reproducible.
+type AttackRequest struct {
+	Attacker
+	Difficulty *int64 `json:"difficulty"`
+	Challenge  int64  `json:"challenge"`
+	Boost      int64  `json:"boost"`
+	Setback    int64  `json:"setback"`
+	Seed       *int64 `json:"seed"`
+}
+
+// Validate checks the attack request, no characteristic, skill or dice count may exceed MaxPoolSize
+func (a *AttackRequest) Validate() error {
+	validationErr := model.ValidationError{}
+
+	for _, name := range sortedNames(a.Characteristics) {
+		if a.Characteristics[name] < 1 || a.Characteristics[name] > MaxPoolSize {
+			validationErr.Add("characteristics."+name, fmt.Sprintf("must be between 1 and %v", MaxPoolSize))
+		}
+	}
+	for _, name := range sortedNames(a.Skills) {
+		validateDice(&validationErr, "skills."+name, a.Skills[name])
+	}
+	if a.Difficulty != nil {
+		validateDice(&validationErr, "difficulty", *a.Difficulty)
+	}
+	validateDice(&validationErr, "challenge", a.Challenge)
+	validateDice(&validationErr, "boost", a.Boost)
+	validateDice(&validationErr, "setback", a.Setback)
+
+	return validationErr.OrNil()
+}
+
+// validateDice checks a number of dice is between 0 and MaxPoolSize
+func validateDice(validationErr *model.ValidationError, field string, count int64) {
+	if count < 0 {
+		validationErr.Add(field, "must not be negative")
+		return
+	}
+	if count > MaxPoolSize {
+		validationErr.Add(field, fmt.Sprintf("must be at most %v", MaxPoolSize))
+	}
+}
+
+// Pool builds the dice pool for an attack with the given weapon
+func (a *AttackRequest) Pool(weapon *model.Weapon) (dice.Pool, string, error) {
+	characteristic, ok := SkillCharacteristics[NormalizeSkill(weapon.Skill)]
+	if !ok {
+		return dice.Pool{}, "", model.ValidationError{{Field: "skill", Message: fmt.Sprintf("%v is not a combat skill", weapon.Skill)}}
+	}
+
+	pool := dice.SkillPool(a.Characteristic(characteristic), a.Ranks(weapon.Skill))
+	pool.Difficulty = DefaultDifficulty
+	if a.Difficulty != nil {
+		pool.Difficulty = *a.Difficulty
+	}
+	pool.Challenge = a.Challenge
+	pool.Boost = a.Boost
+	pool.Setback = a.Setback
+
+	err := validatePool(pool)
+	if err != nil {
+		return dice.Pool{}, "", err
+	}
+
+	return pool, characteristic, nil
+}
+
+// validatePool checks the built pool holds at most MaxPoolSize dice of every type, dice added after the request was
+// validated, such as the setback from a target's defense, are counted too
+func validatePool(pool dice.Pool) error {
+	validationErr := model.ValidationError{}
+	counts := []struct {
+		field string
+		count int64
+	}{
+		{"ability", pool.Ability},
+		{"proficiency", pool.Proficiency},
+		{"difficulty", pool.Difficulty},
+		{"challenge", pool.Challenge},
+		{"boost", pool.Boost},
+		{"setback", pool.Setback},
+		{"force", pool.Force},
+	}
+	for _, count := range counts {
+		if count.count > MaxPoolSize {
+			validationErr.Add(count.field, fmt.Sprintf("the pool holds %v %v dice, at most %v can be rolled", count.count, count.field, MaxPoolSize))
+		}
+	}
+	return validationErr.OrNil()
+}
+
+// AttackResult is the outcome of an attack
+type AttackResult struct {
+	Weapon            string      `json:"weapon"`
+	Skill             string      `json:"skill"`
+	Characteristic    string      `json:"characteristic"`
+	Seed              int64       `json:"seed"`
+	Pool              dice.Pool   `json:"pool"`
+	Roll              dice.Result `json:"roll"`
+	NetSuccesses      int64       `json:"netSuccesses"`
+	NetAdvantages     int64       `json:"netAdvantages"`
+	Triumphs          int64       `json:"triumphs"`
+	Despairs          int64       `json:"despairs"`
+	Hit               bool        `json:"hit"`
+	Damage            int64       `json:"damage"`
+	CriticalTriggered bool        `json:"criticalTriggered"`
+}
+
+// Attack rolls an attack with the weapon.
+// A hit deals the weapon's damage plus one per net success. The weapon's Critical rating is triggered
+// on a hit when the net advantages reach the rating or a triumph is rolled.
+func Attack(weapon *model.Weapon, request AttackRequest, roller *dice.Roller) (AttackResult, error) {
+	pool, characteristic, err := request.Pool(weapon)
+	if err != nil {
+		return AttackResult{}, err
+	}
+
+	roll := roller.Roll(pool)
+	result := AttackResult{
+		Weapon:         weapon.Name,
+		Skill:          weapon.Skill,
+		Characteristic: characteristic,
+		Pool:           pool,
+		Roll:           roll,
+		NetSuccesses:   roll.NetSuccesses(),
+		NetAdvantages:  roll.NetAdvantages(),
+		Triumphs:       roll.Triumphs,
+		Despairs:       roll.Despairs,
+		Hit:            roll.Succeeded(),
+	}
+
+	if result.Hit {
+		result.Damage = weapon.EffectiveDamage(request.Characteristic(Brawn)) + result.NetSuccesses
+		result.CriticalTriggered = roll.Triumphs > 0 || (weapon.Critical > 0 && result.NetAdvantages >= weapon.Critical)
+	}
+
+	return result, nil
+}
+
+// sortedNames returns the keys of a ratings map in order so validation errors are reported consistently
+func sortedNames(ratings map[string]int64) []string {
+	names := make([]string, 0, len(ratings))
+	for name := range ratings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,101 @@
+package combat
+
+import (
+	"reflect"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/dice"
+)
+
+func TestAttackRequest_Pool(t *testing.T) {
+	difficulty := int64(3)
+	request := AttackRequest{
+		Attacker: Attacker{
+			Characteristics: map[string]int64{"Agility": 3, "Brawn": 2},
+			Skills:          map[string]int64{"Ranged (Light)": 2},
+		},
+		Difficulty: &difficulty,
+		Setback:    1,
+	}
+
+	pool, characteristic, err := request.Pool(&model.Weapon{Skill: "Ranged [Light]"})
+	expected := dice.Pool{Ability: 1, Proficiency: 2, Difficulty: 3, Setback: 1}
+	if err != nil || characteristic != Agility || pool != expected {
+		t.Errorf("Pool() error:\ngot: %+v, %v, %v\nexpected: %+v, %v", pool, characteristic, err, expected, Agility)
+	}
+
+	plain := AttackRequest{Attacker: request.Attacker}
+	pool, _, _ = plain.Pool(&model.Weapon{Skill: "Brawl"})
+	if pool != (dice.Pool{Ability: 2, Difficulty: DefaultDifficulty}) {
+		t.Errorf("Pool() error:\ngot: %+v\nexpected: 2 ability dice against an Average check", pool)
+	}
+
+	_, _, err = request.Pool(&model.Weapon{Skill: "Astrogation"})
+	if err == nil {
+		t.Errorf("Pool() error:\ngot: <no error>\nexpected: an error for a non combat skill")
+	}
+}
+
+func TestAttackRequest_Validate_PoolSize(t *testing.T) {
+	difficulty := int64(1000000000000000)
+	request := AttackRequest{
+		Attacker: Attacker{
+			Characteristics: map[string]int64{"Agility": MaxPoolSize + 1},
+			Skills:          map[string]int64{"Ranged (Light)": MaxPoolSize + 1},
+		},
+		Difficulty: &difficulty,
+		Challenge:  MaxPoolSize + 1,
+		Boost:      MaxPoolSize,
+		Setback:    -1,
+	}
+
+	err := request.Validate()
+	validationErr, ok := err.(model.ValidationError)
+	if !ok || len(validationErr) != 5 {
+		t.Errorf("Validate() error:\ngot: %v\nexpected: characteristic, skill, difficulty, challenge and setback errors", err)
+	}
+}
+
+func TestAttack(t *testing.T) {
+	weapon := &model.Weapon{Name: "Vibroknife", Skill: "Melee", Damage: model.Damage{Base: 1, BrawnRelative: true}, Critical: 2}
+	request := AttackRequest{Attacker: Attacker{
+		Characteristics: map[string]int64{"brawn": 3},
+		Skills:          map[string]int64{"melee": 2},
+	}}
+
+	hits, crits := 0, 0
+	for seed := int64(0); seed < 100; seed++ {
+		result, err := Attack(weapon, request, dice.NewRoller(seed))
+		if err != nil {
+			t.Fatalf("Attack() error:\ngot: %v\nexpected: <no error>", err)
+		}
+
+		repeat, _ := Attack(weapon, request, dice.NewRoller(seed))
+		if !reflect.DeepEqual(result, repeat) {
+			t.Errorf("Attack() error:\ngot: %+v and %+v\nexpected: the same result for seed %v", result, repeat, seed)
+		}
+
+		if result.Hit != (result.NetSuccesses > 0) {
+			t.Errorf("Attack() error:\ngot: hit %v with %v net successes", result.Hit, result.NetSuccesses)
+		}
+		if result.Hit && result.Damage != 4+result.NetSuccesses {
+			t.Errorf("Attack() error:\ngot: damage %v\nexpected: %v", result.Damage, 4+result.NetSuccesses)
+		}
+		critical := result.Hit && (result.Triumphs > 0 || result.NetAdvantages >= 2)
+		if result.CriticalTriggered != critical {
+			t.Errorf("Attack() error:\ngot: critical %v for %+v\nexpected: %v", result.CriticalTriggered, result, critical)
+		}
+
+		if result.Hit {
+			hits++
+		}
+		if result.CriticalTriggered {
+			crits++
+		}
+	}
+
+	if hits == 0 || crits == 0 {
+		t.Errorf("Attack() error:\ngot: %v hits and %v criticals in 100 attacks\nexpected: both to occur", hits, crits)
+	}
+}
@@ -0,0 +1,82 @@
+package combat
+
+import (
+	"strconv"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+)
+
+// MatrixCell compares one weapon against one armor
+type MatrixCell struct {
+	WeaponID       string `json:"weaponId"`
+	Weapon         string `json:"weapon"`
+	ArmorID        string `json:"armorId"`
+	Armor          string `json:"armor"`
+	Damage         int64  `json:"damage"`
+	Soak           int64  `json:"soak"`
+	DamagePerHit   int64  `json:"damagePerHit"`
+	DefenseSetback int64  `json:"defenseSetback"`
+}
+
+// Matrix is the comparison of every weapon against every armor, one row per weapon
+type Matrix struct {
+	Brawn       int64          `json:"brawn"`
+	TargetBrawn int64          `json:"targetBrawn"`
+	Rows        [][]MatrixCell `json:"rows"`
+}
+
+// BuildMatrix compares every weapon against every armor. The damage per hit is the damage of a hit with a single
+// net success after the target's soak, and the armor's defense is the number of setback dice added to the attack.
+func BuildMatrix(weapons []model.Weapon, armors []model.Armor, brawn, targetBrawn int64) Matrix {
+	matrix := Matrix{Brawn: brawn, TargetBrawn: targetBrawn, Rows: make([][]MatrixCell, 0, len(weapons))}
+
+	for i := range weapons {
+		weapon := &weapons[i]
+		row := make([]MatrixCell, 0, len(armors))
+
+		for j := range armors {
+			armor := &armors[j]
+			cell := MatrixCell{
+				WeaponID:       weapon.ID.Hex(),
+				Weapon:         weapon.Name,
+				ArmorID:        armor.ID.Hex(),
+				Armor:          armor.ArmorType,
+				Damage:         weapon.EffectiveDamage(brawn),
+				Soak:           Soak(weapon, armor, targetBrawn),
+				DefenseSetback: armor.Defense,
+			}
+			cell.DamagePerHit = cell.Damage + 1 - cell.Soak
+			if cell.DamagePerHit < 0 {
+				cell.DamagePerHit = 0
+			}
+			row = append(row, cell)
+		}
+
+		matrix.Rows = append(matrix.Rows, row)
+	}
+
+	return matrix
+}
+
+// CSVHeader is the header row of the CSV form of the matrix
+var CSVHeader = []string{"weaponId", "weapon", "armorId", "armor", "damage", "soak", "damagePerHit", "defenseSetback"}
+
+// Records flattens the matrix into CSV records with one line per pairing, starting with CSVHeader
+func (m Matrix) Records() [][]string {
+	records := [][]string{CSVHeader}
+	for _, row := range m.Rows {
+		for _, cell := range row {
+			records = append(records, []string{
+				cell.WeaponID,
+				cell.Weapon,
+				cell.ArmorID,
+				cell.Armor,
+				strconv.FormatInt(cell.Damage, 10),
+				strconv.FormatInt(cell.Soak, 10),
+				strconv.FormatInt(cell.DamagePerHit, 10),
+				strconv.FormatInt(cell.DefenseSetback, 10),
+			})
+		}
+	}
+	return records
+}
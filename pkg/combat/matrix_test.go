@@ -0,0 +1,38 @@
+package combat
+
+import (
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildMatrix(t *testing.T) {
+	weapons := []model.Weapon{
+		{ID: primitive.NewObjectID(), Name: "Blaster Pistol", Damage: model.Damage{Base: 6}},
+		{ID: primitive.NewObjectID(), Name: "Vibroknife", Damage: model.Damage{Base: 1, BrawnRelative: true}, Qualities: []model.Quality{{Name: "Pierce", Rating: 2}}},
+	}
+	armors := []model.Armor{
+		{ID: primitive.NewObjectID(), ArmorType: "Padded Armor", Soak: 2},
+		{ID: primitive.NewObjectID(), ArmorType: "Laminate", Soak: 2, Defense: 1},
+	}
+
+	matrix := BuildMatrix(weapons, armors, 3, 2)
+	if len(matrix.Rows) != 2 || len(matrix.Rows[0]) != 2 {
+		t.Fatalf("BuildMatrix() error:\ngot: %+v\nexpected: a 2 by 2 matrix", matrix)
+	}
+
+	pistol := matrix.Rows[0][1]
+	if pistol.Damage != 6 || pistol.Soak != 4 || pistol.DamagePerHit != 3 || pistol.DefenseSetback != 1 {
+		t.Errorf("BuildMatrix() error:\ngot: %+v\nexpected: 3 damage per hit through soak 4 with 1 setback", pistol)
+	}
+	knife := matrix.Rows[1][0]
+	if knife.Damage != 4 || knife.Soak != 2 || knife.DamagePerHit != 3 {
+		t.Errorf("BuildMatrix() error:\ngot: %+v\nexpected: 3 damage per hit through soak 2", knife)
+	}
+
+	records := matrix.Records()
+	if len(records) != 5 || records[0][0] != "weaponId" || records[2][3] != "Laminate" || records[2][6] != "3" {
+		t.Errorf("Records() error:\ngot: %v\nexpected: a header and one record per pairing", records)
+	}
+}
@@ -0,0 +1,264 @@
+package combat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/dice"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The simulation limits
+const (
+	DefaultRuns       = 10000
+	MaxRuns           = 1000000
+	DefaultTimeBudget = 2 * time.Second
+	MaxTimeBudget     = 10 * time.Second
+	// chunkSize is the number of attacks rolled from one seeded roller, results do not depend on the number of workers
+	chunkSize = 1000
+)
+
+// BreachSoak is the soak ignored per rating of the Breach quality, breach is rated in vehicle armor
+const BreachSoak = 10
+
+// SimulationRequest is the request body used to simulate attacks with a weapon against a target wearing armor.
+// The armor is optional, without it the target soaks with Brawn alone.
+type SimulationRequest struct {
+	AttackRequest
+	WeaponID     primitive.ObjectID `json:"weaponId"`
+	ArmorID      primitive.ObjectID `json:"armorId"`
+	TargetBrawn  int64              `json:"targetBrawn"`
+	Runs         int64              `json:"runs"`
+	TimeBudgetMS int64              `json:"timeBudgetMs"`
+}
+
+// Validate checks the simulation request and applies the default runs
+func (s *SimulationRequest) Validate() error {
+	validationErr := model.ValidationError{}
+	validationErr.Merge("attacker", s.AttackRequest.Validate())
+
+	if s.WeaponID.IsZero() {
+		validationErr.Add("weaponId", "is required")
+	}
+	if s.TargetBrawn < 0 {
+		validationErr.Add("targetBrawn", "must not be negative")
+	}
+
+	if s.Runs == 0 {
+		s.Runs = DefaultRuns
+	}
+	if s.Runs < 1 || s.Runs > MaxRuns {
+		validationErr.Add("runs", fmt.Sprintf("must be between 1 and %v", MaxRuns))
+	}
+	if s.TimeBudgetMS < 0 {
+		validationErr.Add("timeBudgetMs", "must not be negative")
+	}
+
+	return validationErr.OrNil()
+}
+
+// TimeBudget returns the time the simulation may run for, capped at MaxTimeBudget
+func (s *SimulationRequest) TimeBudget() time.Duration {
+	budget := time.Duration(s.TimeBudgetMS) * time.Millisecond
+	if budget <= 0 {
+		return DefaultTimeBudget
+	}
+	if budget > MaxTimeBudget {
+		return MaxTimeBudget
+	}
+	return budget
+}
+
+// Percentiles is the distribution of damage dealt after soak
+type Percentiles struct {
+	P10 int64 `json:"p10"`
+	P25 int64 `json:"p25"`
+	P50 int64 `json:"p50"`
+	P75 int64 `json:"p75"`
+	P90 int64 `json:"p90"`
+	P99 int64 `json:"p99"`
+}
+
+// SimulationResult summarizes the simulated attacks
+type SimulationResult struct {
+	Weapon          string      `json:"weapon"`
+	Armor           string      `json:"armor,omitempty"`
+	Seed            int64       `json:"seed"`
+	Runs            int64       `json:"runs"`
+	CompletedRuns   int64       `json:"completedRuns"`
+	Truncated       bool        `json:"truncated"`
+	Soak            int64       `json:"soak"`
+	DefenseSetback  int64       `json:"defenseSetback"`
+	HitProbability  float64     `json:"hitProbability"`
+	CritProbability float64     `json:"critProbability"`
+	ExpectedDamage  float64     `json:"expectedDamage"`
+	Percentiles     Percentiles `json:"percentiles"`
+}
+
+// outcome is the result of a single simulated attack
+type outcome struct {
+	hit      bool
+	critical bool
+	damage   int64
+}
+
+// Simulate rolls the requested number of attacks with the weapon against the target and summarizes the damage dealt.
+// The armor's defense adds setback dice to the attack and its soak plus the target's Brawn is subtracted from the damage,
+// reduced by the weapon's Pierce and Breach qualities. A critical injury needs a hit that gets through soak.
+// Attacks are rolled in chunks spread across goroutines, each chunk seeded from the request seed so a seed always
+// produces the same result. When the context is done before every chunk is rolled the result only covers the completed
+// runs and is marked as truncated.
+func Simulate(ctx context.Context, weapon *model.Weapon, armor *model.Armor, request SimulationRequest, seed int64) (SimulationResult, error) {
+	result := SimulationResult{Weapon: weapon.Name, Seed: seed, Runs: request.Runs, Soak: Soak(weapon, armor, request.TargetBrawn)}
+
+	attack := request.AttackRequest
+	if armor != nil {
+		result.Armor = armor.ArmorType
+		result.DefenseSetback = armor.Defense
+		attack.Setback += armor.Defense
+	}
+
+	_, _, err := attack.Pool(weapon)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	chunks := int((request.Runs + chunkSize - 1) / chunkSize)
+	outcomes := make([][]outcome, chunks)
+	work := make(chan int)
+
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < runtime.NumCPU(); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range work {
+				outcomes[chunk] = simulateChunk(ctx, weapon, attack, result.Soak, seed+int64(chunk), chunkRuns(chunk, request.Runs))
+			}
+		}()
+	}
+
+	for chunk := 0; chunk < chunks; chunk++ {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case work <- chunk:
+		case <-ctx.Done():
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	summarize(&result, outcomes)
+	return result, nil
+}
+
+// chunkRuns returns the number of attacks rolled in a chunk, the last chunk holds the remainder
+func chunkRuns(chunk int, runs int64) int64 {
+	remaining := runs - int64(chunk)*chunkSize
+	if remaining < chunkSize {
+		return remaining
+	}
+	return chunkSize
+}
+
+// simulateChunk rolls a chunk of attacks, an interrupted chunk is discarded so results stay reproducible
+func simulateChunk(ctx context.Context, weapon *model.Weapon, attack AttackRequest, soak, seed, runs int64) []outcome {
+	roller := dice.NewRoller(seed)
+	outcomes := make([]outcome, 0, runs)
+
+	for i := int64(0); i < runs; i++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		result, _ := Attack(weapon, attack, roller)
+		damage := result.Damage - soak
+		if !result.Hit || damage < 0 {
+			damage = 0
+		}
+		outcomes = append(outcomes, outcome{hit: result.Hit, critical: result.CriticalTriggered && damage > 0, damage: damage})
+	}
+
+	return outcomes
+}
+
+// summarize fills in the probabilities, expected damage and percentiles of the completed runs
+func summarize(result *SimulationResult, chunks [][]outcome) {
+	damage := []int64{}
+	var hits, criticals, total int64
+
+	for _, chunk := range chunks {
+		for _, attack := range chunk {
+			damage = append(damage, attack.damage)
+			total += attack.damage
+			if attack.hit {
+				hits++
+			}
+			if attack.critical {
+				criticals++
+			}
+		}
+	}
+
+	result.CompletedRuns = int64(len(damage))
+	result.Truncated = result.CompletedRuns < result.Runs
+	if result.CompletedRuns == 0 {
+		return
+	}
+
+	runs := float64(result.CompletedRuns)
+	result.HitProbability = float64(hits) / runs
+	result.CritProbability = float64(criticals) / runs
+	result.ExpectedDamage = float64(total) / runs
+
+	sort.Slice(damage, func(i, j int) bool { return damage[i] < damage[j] })
+	result.Percentiles = Percentiles{
+		P10: percentile(damage, 10),
+		P25: percentile(damage, 25),
+		P50: percentile(damage, 50),
+		P75: percentile(damage, 75),
+		P90: percentile(damage, 90),
+		P99: percentile(damage, 99),
+	}
+}
+
+// percentile returns the nearest rank percentile of sorted values
+func percentile(sorted []int64, p float64) int64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Soak returns the soak of a target with the given Brawn wearing the armor against the weapon, the armor is optional.
+// The weapon's Pierce and Breach qualities ignore part of the soak.
+func Soak(weapon *model.Weapon, armor *model.Armor, targetBrawn int64) int64 {
+	soak := targetBrawn
+	if armor != nil {
+		soak += armor.Soak
+	}
+
+	for _, quality := range weapon.Qualities {
+		switch strings.ToLower(quality.Name) {
+		case "pierce":
+			soak -= quality.Rating
+		case "breach":
+			soak -= quality.Rating * BreachSoak
+		}
+	}
+
+	if soak < 0 {
+		return 0
+	}
+	return soak
+}
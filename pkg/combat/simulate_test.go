@@ -0,0 +1,97 @@
+package combat
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func simulationRequest(runs int64) SimulationRequest {
+	return SimulationRequest{
+		AttackRequest: AttackRequest{Attacker: Attacker{
+			Characteristics: map[string]int64{"agility": 3},
+			Skills:          map[string]int64{"ranged (heavy)": 2},
+		}},
+		WeaponID:    primitive.NewObjectID(),
+		TargetBrawn: 2,
+		Runs:        runs,
+	}
+}
+
+func TestSimulate_Deterministic(t *testing.T) {
+	weapon := &model.Weapon{Name: "Blaster Rifle", Skill: "Ranged (Heavy)", Damage: model.Damage{Base: 9}, Critical: 3}
+	armor := &model.Armor{ArmorType: "Padded Armor", Soak: 2, Defense: 1}
+
+	first, err := Simulate(context.Background(), weapon, armor, simulationRequest(2500), 11)
+	if err != nil {
+		t.Fatalf("Simulate() error:\ngot: %v\nexpected: <no error>", err)
+	}
+	second, _ := Simulate(context.Background(), weapon, armor, simulationRequest(2500), 11)
+
+	if first != second {
+		t.Errorf("Simulate() error:\ngot: %+v and %+v\nexpected: the same result for the same seed", first, second)
+	}
+	if first.CompletedRuns != 2500 || first.Truncated || first.Soak != 4 || first.DefenseSetback != 1 {
+		t.Errorf("Simulate() error:\ngot: %+v\nexpected: 2500 completed runs against soak 4 and defense 1", first)
+	}
+	if first.HitProbability <= 0 || first.HitProbability >= 1 || first.CritProbability > first.HitProbability {
+		t.Errorf("Simulate() error:\ngot: hit %v crit %v\nexpected: probabilities between 0 and 1", first.HitProbability, first.CritProbability)
+	}
+	p := first.Percentiles
+	if p.P10 > p.P50 || p.P50 > p.P90 || p.P90 > p.P99 || p.P99 < 6 {
+		t.Errorf("Simulate() error:\ngot: %+v\nexpected: ordered percentiles", p)
+	}
+}
+
+func TestSimulate_Pierce(t *testing.T) {
+	weapon := &model.Weapon{Name: "Vibro-ax", Skill: "Melee", Damage: model.Damage{Base: 3, BrawnRelative: true}, Critical: 2,
+		Qualities: []model.Quality{{Name: "Pierce", Rating: 2}}}
+	armor := &model.Armor{ArmorType: "Laminate", Soak: 2}
+
+	request := simulationRequest(100)
+	request.Characteristics = map[string]int64{"brawn": 3}
+	result, err := Simulate(context.Background(), weapon, armor, request, 1)
+	if err != nil || result.Soak != 2 {
+		t.Errorf("Simulate() error:\ngot: %+v, %v\nexpected: soak of 2 after pierce", result, err)
+	}
+}
+
+func TestSimulate_Cancelled(t *testing.T) {
+	weapon := &model.Weapon{Name: "Blaster Rifle", Skill: "Ranged (Heavy)", Damage: model.Damage{Base: 9}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := Simulate(ctx, weapon, nil, simulationRequest(5000), 1)
+	if err != nil || !result.Truncated || result.CompletedRuns != 0 {
+		t.Errorf("Simulate() error:\ngot: %+v, %v\nexpected: a truncated result", result, err)
+	}
+}
+
+func TestSimulate_PoolTooLarge(t *testing.T) {
+	weapon := &model.Weapon{Name: "Blaster Rifle", Skill: "Ranged (Heavy)", Damage: model.Damage{Base: 9}}
+	armor := &model.Armor{ArmorType: "Fortress Plating", Defense: MaxPoolSize}
+	request := simulationRequest(5000)
+	request.Setback = 1
+
+	_, err := Simulate(context.Background(), weapon, armor, request, 1)
+	if _, ok := err.(model.ValidationError); !ok {
+		t.Errorf("Simulate() error:\ngot: %v\nexpected: a setback validation error", err)
+	}
+}
+
+func TestSimulationRequest_Validate(t *testing.T) {
+	request := SimulationRequest{TargetBrawn: -1, Runs: MaxRuns + 1}
+	err := request.Validate()
+
+	validationErr, ok := err.(model.ValidationError)
+	if !ok || len(validationErr) != 3 {
+		t.Errorf("Validate() error:\ngot: %v\nexpected: weaponId, targetBrawn and runs errors", err)
+	}
+
+	request = simulationRequest(0)
+	if err = request.Validate(); err != nil || request.Runs != DefaultRuns {
+		t.Errorf("Validate() error:\ngot: %v, %v runs\nexpected: %v default runs", err, request.Runs, DefaultRuns)
+	}
+}
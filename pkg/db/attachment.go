@@ -0,0 +1,231 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertAttachment is the database implementation to insert an attachment object
+func (g *GearDB) InsertAttachment(attachment *model.Attachment) error {
+	logrus.Debug("BEGIN - InsertAttachment")
+
+	collection := g.client.Database(g.databaseName).Collection(g.attachmentCollection)
+
+	_, err := collection.InsertOne(context.Background(), attachment)
+
+	return err
+}
+
+//GetAttachment is the database implementation to get all attachment objects
+func (g *GearDB) GetAttachment(queryParams url.Values) ([]model.Attachment, error) {
+	logrus.Debug("BEGIN - GetAttachment")
+
+	collection := g.client.Database(g.databaseName).Collection(g.attachmentCollection)
+
+	queryParams, legal, err := legalFilter(queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, attachmentFields)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := api.ParseSort(sortParam, attachmentSortFields)
+	if err != nil {
+		return nil, err
+	}
+	filter = api.MergeFilters(filter, legal)
+	skip := 0
+	if pageNumber > 0 {
+		skip = (pageNumber - 1) * pageCount
+	}
+
+	opts := options.Find().
+		SetMaxTime(30 * time.Second).
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageCount)).
+		SetSort(sort.Document())
+
+	cur, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.Attachment{}
+
+	for cur.Next(context.Background()) {
+		elem := model.Attachment{}
+		err := cur.Decode(&elem)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, elem)
+	}
+
+	return matches, nil
+}
+
+//GetAttachmentByID is the database implementation to get a specific attachment back from the database
+func (g *GearDB) GetAttachmentByID(mongoID primitive.ObjectID) (*model.Attachment, error) {
+	logrus.Debugf("BEGIN - GetAttachmentByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.attachmentCollection)
+	query := api.BuildQuery(&mongoID, nil)
+
+	attachment := model.Attachment{}
+
+	err := collection.FindOne(context.Background(), query).Decode(&attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, err
+}
+
+//UpdateAttachmentByID updates a specific attachment in the attachment database
+func (g *GearDB) UpdateAttachmentByID(attachment model.Attachment, mongoID primitive.ObjectID) error {
+
+	collection := g.client.Database(g.databaseName).Collection(g.attachmentCollection)
+
+	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+		Key:   "$set",
+		Value: attachment,
+	}})
+	if err != nil {
+		return err
+	}
+
+	matched := strconv.FormatInt(result.MatchedCount, 10)
+	modified := strconv.FormatInt(result.ModifiedCount, 10)
+
+	if result.MatchedCount != 1 {
+		return errors.New("Could not update attachment. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
+	}
+
+	if result.ModifiedCount != 1 {
+		return errors.New("Could not update attachment. Tried to updated " + mongoID.Hex() + " tried to update " + modified + " number of results instead of 1")
+	}
+
+	return nil
+}
+
+//DeleteAttachmentByID deletes a specific attachment from the database
+func (g *GearDB) DeleteAttachmentByID(mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - DeleteAttachmentByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.attachmentCollection)
+
+	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+
+	return err
+}
+
+//CountAttachmentItems counts the catalog weapons and armor with a specific attachment installed
+func (g *GearDB) CountAttachmentItems(attachmentID primitive.ObjectID) (int64, error) {
+	logrus.Debugf("BEGIN - CountAttachmentItems: %v", attachmentID)
+
+	var count int64
+	for _, collectionName := range []string{g.weaponCollection, g.armorCollection} {
+		collection := g.client.Database(g.databaseName).Collection(collectionName)
+
+		items, err := collection.CountDocuments(context.Background(), bson.M{"attachments.attachmentId": attachmentID}, options.Count().SetMaxTime(30*time.Second))
+		if err != nil {
+			return 0, err
+		}
+		count += items
+	}
+
+	return count, nil
+}
+
+//UpdateArmorAttachments replaces the attachments installed on a specific armor, prior are the attachments the change was
+//made from and the update fails with api.ErrConflict when another request changed them first
+func (g *GearDB) UpdateArmorAttachments(attachments, prior []model.InstalledAttachment, mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - UpdateArmorAttachments: %v", mongoID)
+
+	return g.updateAttachments(g.armorCollection, attachments, prior, mongoID)
+}
+
+//UpdateWeaponAttachments replaces the attachments installed on a specific weapon, prior are the attachments the change was
+//made from and the update fails with api.ErrConflict when another request changed them first
+func (g *GearDB) UpdateWeaponAttachments(attachments, prior []model.InstalledAttachment, mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - UpdateWeaponAttachments: %v", mongoID)
+
+	return g.updateAttachments(g.weaponCollection, attachments, prior, mongoID)
+}
+
+// hardPointsFilter selects the document by id, when the update keeps the stored attachments it also requires that they
+// fit in the new hard points
+func hardPointsFilter(mongoID primitive.ObjectID, hardPoints int64, attachments []model.InstalledAttachment) bson.M {
+	filter := bson.M{"_id": mongoID}
+	if len(attachments) == 0 {
+		// the attachments are omitted from the update so the stored ones are kept
+		filter["$expr"] = bson.M{"$lte": bson.A{bson.M{"$sum": "$attachments.hardPoints"}, hardPoints}}
+	}
+	return filter
+}
+
+// checkHardPoints explains an update missed by hardPointsFilter, it returns a validation error on the field when the
+// document exists and nil when it does not
+func checkHardPoints(collection *mongo.Collection, mongoID primitive.ObjectID, field string, hardPoints int64) error {
+	count, err := collection.CountDocuments(context.Background(), bson.M{"_id": mongoID})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+	return model.ValidationError{{Field: field, Message: fmt.Sprintf("installed attachments use more than %v hard points, remove attachments first", hardPoints)}}
+}
+
+// updateAttachments sets the attachments only while the stored attachments are still the prior ones, so two installs
+// checked against the same free hard points cannot both be written
+func (g *GearDB) updateAttachments(collectionName string, attachments, prior []model.InstalledAttachment, mongoID primitive.ObjectID) error {
+	collection := g.client.Database(g.databaseName).Collection(collectionName)
+
+	if attachments == nil {
+		attachments = []model.InstalledAttachment{}
+	}
+
+	filter := bson.M{"_id": mongoID, "attachments": prior}
+	if len(prior) == 0 {
+		// items without attachments may not store the array at all
+		filter = bson.M{"_id": mongoID, "$or": []bson.M{
+			{"attachments": nil},
+			{"attachments": bson.M{"$size": 0}},
+		}}
+	}
+
+	result, err := collection.UpdateOne(context.Background(), filter, bson.M{"$set": bson.M{"attachments": attachments}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount != 1 {
+		count, err := collection.CountDocuments(context.Background(), bson.M{"_id": mongoID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return mongo.ErrNoDocuments
+		}
+		return fmt.Errorf("could not update the attachments of %v: %w", mongoID.Hex(), api.ErrConflict)
+	}
+
+	return nil
+}
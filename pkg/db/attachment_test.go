@@ -0,0 +1,26 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_hardPointsFilter(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	expected := bson.M{"_id": id, "$expr": bson.M{"$lte": bson.A{bson.M{"$sum": "$attachments.hardPoints"}, int64(2)}}}
+	if filter := hardPointsFilter(id, 2, nil); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("hardPointsFilter() error:\ngot: %v\nexpected: %v", filter, expected)
+	}
+
+	// attachments sent with the update replace the stored ones and are checked by Validate
+	attachments := []model.InstalledAttachment{{AttachmentID: primitive.NewObjectID(), Name: "Custom Grip", HardPoints: 1}}
+	expected = bson.M{"_id": id}
+	if filter := hardPointsFilter(id, 2, attachments); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("hardPointsFilter() error:\ngot: %v\nexpected: %v", filter, expected)
+	}
+}
@@ -37,10 +37,18 @@ func InitializeClients(ctx context.Context) (*mongo.Client, error) {
 func InitializeDatabases(client *mongo.Client, config *config.Config) *GearDB {
 
 	database := &GearDB{
-		client:           client,
-		databaseName:     config.GearDatabase,
-		armorCollection:  config.ArmorCollection,
-		weaponCollection: config.WeaponCollection,
+		client:               client,
+		databaseName:         config.GearDatabase,
+		armorCollection:      config.ArmorCollection,
+		weaponCollection:     config.WeaponCollection,
+		attachmentCollection: config.AttachmentCollection,
+		gearCollection:       config.GearCollection,
+		vehicleCollection:    config.VehicleCollection,
+		inventoryCollection:  config.InventoryCollection,
+		templateCollection:   config.TemplateCollection,
+		shopCollection:       config.ShopCollection,
+		lootCollection:       config.LootCollection,
+		instanceCollection:   config.InstanceCollection,
 	}
 
 	return database
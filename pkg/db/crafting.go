@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertTemplate is the database implementation to insert a crafting template
+func (g *GearDB) InsertTemplate(template *model.CraftingTemplate) error {
+	logrus.Debug("BEGIN - InsertTemplate")
+
+	collection := g.client.Database(g.databaseName).Collection(g.templateCollection)
+
+	_, err := collection.InsertOne(context.Background(), template)
+
+	return err
+}
+
+//GetTemplate is the database implementation to get all crafting templates
+func (g *GearDB) GetTemplate(queryParams url.Values) ([]model.CraftingTemplate, error) {
+	logrus.Debug("BEGIN - GetTemplate")
+
+	collection := g.client.Database(g.databaseName).Collection(g.templateCollection)
+
+	queryParams, legal, err := legalFilter(queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, templateFields)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := api.ParseSort(sortParam, templateSortFields)
+	if err != nil {
+		return nil, err
+	}
+	filter = api.MergeFilters(filter, legal)
+	skip := 0
+	if pageNumber > 0 {
+		skip = (pageNumber - 1) * pageCount
+	}
+
+	opts := options.Find().
+		SetMaxTime(30 * time.Second).
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageCount)).
+		SetSort(sort.Document())
+
+	cur, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.CraftingTemplate{}
+
+	for cur.Next(context.Background()) {
+		elem := model.CraftingTemplate{}
+		err := cur.Decode(&elem)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, elem)
+	}
+
+	return matches, nil
+}
+
+//GetTemplateByID is the database implementation to get a specific crafting template back from the database
+func (g *GearDB) GetTemplateByID(mongoID primitive.ObjectID) (*model.CraftingTemplate, error) {
+	logrus.Debugf("BEGIN - GetTemplateByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.templateCollection)
+	query := api.BuildQuery(&mongoID, nil)
+
+	template := model.CraftingTemplate{}
+
+	err := collection.FindOne(context.Background(), query).Decode(&template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, err
+}
+
+//UpdateTemplateByID updates a specific crafting template in the template database
+func (g *GearDB) UpdateTemplateByID(template model.CraftingTemplate, mongoID primitive.ObjectID) error {
+
+	collection := g.client.Database(g.databaseName).Collection(g.templateCollection)
+
+	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+		Key:   "$set",
+		Value: template,
+	}})
+	if err != nil {
+		return err
+	}
+
+	matched := strconv.FormatInt(result.MatchedCount, 10)
+	modified := strconv.FormatInt(result.ModifiedCount, 10)
+
+	if result.MatchedCount != 1 {
+		return errors.New("Could not update template. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
+	}
+
+	if result.ModifiedCount != 1 {
+		return errors.New("Could not update template. Tried to updated " + mongoID.Hex() + " tried to update " + modified + " number of results instead of 1")
+	}
+
+	return nil
+}
+
+//DeleteTemplateByID deletes a specific crafting template from the database
+func (g *GearDB) DeleteTemplateByID(mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - DeleteTemplateByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.templateCollection)
+
+	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+
+	return err
+}
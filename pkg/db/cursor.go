@@ -0,0 +1,129 @@
+package db
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const cursorParam = "cursor"
+
+// cursor marks the last document of a page by its value for every sort key, the next page starts after them. It is handed
+// out as base64 encoded BSON so the values keep their stored types and the token stays opaque to clients.
+type cursor struct {
+	Sort   string        `bson:"s"`
+	Values []interface{} `bson:"v"`
+}
+
+// cursorQuery consumes the cursor query parameter
+func cursorQuery(queryParams url.Values) (url.Values, *cursor, error) {
+	remaining := cloneValues(queryParams)
+
+	paramValue, ok := remaining[cursorParam]
+	if !ok {
+		return remaining, nil, nil
+	}
+	delete(remaining, cursorParam)
+
+	after, err := decodeCursor(paramValue[0])
+	if err != nil {
+		return nil, nil, model.ValidationError{{Field: cursorParam, Message: "is not a valid cursor"}}
+	}
+	return remaining, after, nil
+}
+
+// decodeCursor reads a cursor token returned as nextCursor
+func decodeCursor(token string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	after := cursor{}
+	err = bson.Unmarshal(raw, &after)
+	if err != nil {
+		return nil, err
+	}
+	if after.Sort == "" || len(after.Values) == 0 {
+		return nil, errors.New("cursor is incomplete")
+	}
+	return &after, nil
+}
+
+// encode returns the opaque token for the cursor
+func (c cursor) encode() (string, error) {
+	raw, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// filter returns the condition selecting the documents sorted after the cursor, a nil cursor selects every document.
+// A document comes after the cursor when it shares the values of the first sort keys and the next key sorts after the
+// cursor's value. Missing values sort before every other value, ascending or descending in the same way mongo sorts them.
+func (c *cursor) filter(sort api.Sort) (bson.M, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.Sort != sort.String() {
+		return nil, model.ValidationError{{Field: cursorParam, Message: fmt.Sprintf("was issued for sort %v, not %v", c.Sort, sort)}}
+	}
+	if len(c.Values) != len(sort) {
+		return nil, model.ValidationError{{Field: cursorParam, Message: "is not a valid cursor"}}
+	}
+
+	branches := []bson.M{}
+	for i, key := range sort {
+		branch := bson.M{}
+		for j := 0; j < i; j++ {
+			branch[sort[j].Field] = c.Values[j]
+		}
+
+		value := c.Values[i]
+		switch {
+		case !key.Descending && value == nil:
+			branch[key.Field] = bson.M{"$ne": nil}
+		case !key.Descending:
+			branch[key.Field] = bson.M{"$gt": value}
+		case value == nil:
+			// nothing sorts after a missing value in descending order
+			continue
+		default:
+			branch["$or"] = []bson.M{
+				{key.Field: bson.M{"$lt": value}},
+				{key.Field: nil},
+			}
+		}
+		branches = append(branches, branch)
+	}
+
+	return bson.M{"$or": branches}, nil
+}
+
+// nextCursor returns the token of the page ending with the document, the sort values are read from the document as stored
+func nextCursor(sort api.Sort, document bson.Raw) (string, error) {
+	if _, ok := document.Lookup(api.IDField).ObjectIDOK(); !ok {
+		return "", errors.New("document has no object id")
+	}
+
+	after := cursor{Sort: sort.String(), Values: make([]interface{}, len(sort))}
+	for i, key := range sort {
+		// a missing sort field is kept as a null value, it sorts the same way
+		value, err := document.LookupErr(strings.Split(key.Field, ".")...)
+		if err != nil {
+			continue
+		}
+		err = value.Unmarshal(&after.Values[i])
+		if err != nil {
+			return "", err
+		}
+	}
+	return after.encode()
+}
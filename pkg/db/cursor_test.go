@@ -0,0 +1,99 @@
+package db
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_nextCursor_RoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	document, _ := bson.Marshal(bson.M{"_id": id, "name": "Blaster Rifle", "damage": bson.M{"base": int64(9)}})
+	sort := api.Sort{{Field: "damage.base"}, {Field: "_id"}}
+
+	token, err := nextCursor(sort, document)
+	if err != nil {
+		t.Fatalf("nextCursor() error:\ngot: %v\nexpected: <nil>", err)
+	}
+
+	remaining, after, err := cursorQuery(url.Values{"cursor": {token}, "name": {"Blaster Rifle"}})
+	if err != nil {
+		t.Fatalf("cursorQuery() error:\ngot: %v\nexpected: <nil>", err)
+	}
+	if len(remaining) != 1 || remaining.Get("name") != "Blaster Rifle" {
+		t.Errorf("cursorQuery() error:\ngot: %v\nexpected only name to remain", remaining)
+	}
+
+	filter, err := after.filter(sort)
+	expected := bson.M{"$or": []bson.M{
+		{"damage.base": bson.M{"$gt": int64(9)}},
+		{"damage.base": int64(9), "_id": bson.M{"$gt": id}},
+	}}
+	if err != nil || !reflect.DeepEqual(filter, expected) {
+		t.Errorf("filter() error:\ngot: %v, %v\nexpected: %v", filter, err, expected)
+	}
+}
+
+func Test_cursor_filter_Descending(t *testing.T) {
+	id := primitive.NewObjectID()
+	document, _ := bson.Marshal(bson.M{"_id": id, "price": int64(500)})
+	sort := api.Sort{{Field: "price", Descending: true}, {Field: "name"}, {Field: "_id"}}
+
+	token, err := nextCursor(sort, document)
+	if err != nil {
+		t.Fatalf("nextCursor() error:\ngot: %v\nexpected: <nil>", err)
+	}
+	after, err := decodeCursor(token)
+	if err != nil {
+		t.Fatalf("decodeCursor() error:\ngot: %v\nexpected: <nil>", err)
+	}
+
+	filter, err := after.filter(sort)
+	expected := bson.M{"$or": []bson.M{
+		{"$or": []bson.M{{"price": bson.M{"$lt": int64(500)}}, {"price": nil}}},
+		{"price": int64(500), "name": bson.M{"$ne": nil}},
+		{"price": int64(500), "name": nil, "_id": bson.M{"$gt": id}},
+	}}
+	if err != nil || !reflect.DeepEqual(filter, expected) {
+		t.Errorf("filter() error:\ngot: %v, %v\nexpected: %v", filter, err, expected)
+	}
+}
+
+func Test_cursor_filter_DescendingMissingValue(t *testing.T) {
+	id := primitive.NewObjectID()
+	sort := api.Sort{{Field: "rarity", Descending: true}, {Field: "_id"}}
+	after := &cursor{Sort: sort.String(), Values: []interface{}{nil, id}}
+
+	filter, err := after.filter(sort)
+	expected := bson.M{"$or": []bson.M{
+		{"rarity": nil, "_id": bson.M{"$gt": id}},
+	}}
+	if err != nil || !reflect.DeepEqual(filter, expected) {
+		t.Errorf("filter() error:\ngot: %v, %v\nexpected: %v", filter, err, expected)
+	}
+}
+
+func Test_cursor_filter_SortMismatch(t *testing.T) {
+	after := &cursor{Sort: "price,_id", Values: []interface{}{int64(100), primitive.NewObjectID()}}
+
+	if _, err := after.filter(api.Sort{{Field: "price", Descending: true}, {Field: "_id"}}); err == nil {
+		t.Errorf("filter() error:\ngot: <nil>\nexpected: validation error")
+	}
+
+	var none *cursor
+	if filter, err := none.filter(api.Sort{{Field: "_id"}}); filter != nil || err != nil {
+		t.Errorf("filter() error:\ngot: %v, %v\nexpected: no filter", filter, err)
+	}
+}
+
+func Test_cursorQuery_Invalid(t *testing.T) {
+	for _, token := range []string{"not a cursor", "AAAA"} {
+		if _, _, err := cursorQuery(url.Values{"cursor": {token}}); err == nil {
+			t.Errorf("cursorQuery(%v) error:\ngot: <nil>\nexpected: validation error", token)
+		}
+	}
+}
@@ -20,10 +20,18 @@ import (
 
 // GearDB is the data access object for star wars FFG weapons and armor
 type GearDB struct {
-	client           *mongo.Client
-	databaseName     string
-	armorCollection  string
-	weaponCollection string
+	client               *mongo.Client
+	databaseName         string
+	armorCollection      string
+	weaponCollection     string
+	attachmentCollection string
+	gearCollection       string
+	vehicleCollection    string
+	inventoryCollection  string
+	templateCollection   string
+	shopCollection       string
+	lootCollection       string
+	instanceCollection   string
 }
 
 //Ping checks that the database is running
@@ -46,45 +54,126 @@ func (g *GearDB) InsertArmor(armor *model.Armor) error {
 	return err
 }
 
-//GetArmor is the database implementation to get all armor objects
-func (g *GearDB) GetArmor(queryParams url.Values) ([]model.Armor, error) {
+//GetArmor is the database implementation to get all armor objects, the next cursor is empty on the last page
+func (g *GearDB) GetArmor(queryParams url.Values) ([]model.Armor, string, error) {
 	logrus.Debug("BEGIN - GetArmor")
 
 	collection := g.client.Database(g.databaseName).Collection(g.armorCollection)
 
-	pageNumber, pageCount, sort, filter := api.BuildFilter(queryParams)
+	queryParams, after, err := cursorQuery(queryParams)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pageNumber, pageCount, sort, filter, err := armorQuery(queryParams)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keyset, err := after.filter(sort)
+	if err != nil {
+		return nil, "", err
+	}
+	filter = api.MergeFilters(filter, keyset)
+
 	skip := 0
-	if pageNumber > 0 {
+	if pageNumber > 0 && after == nil {
 		skip = (pageNumber - 1) * pageCount
 	}
 
 	opts := options.Find().
 		SetMaxTime(30 * time.Second).
 		SetSkip(int64(skip)).
-		SetLimit(int64(pageCount)).
-		SetSort(bson.D{{
-			Key:   sort,
-			Value: 1,
-		}})
+		SetSort(sort.Document()).
+		SetCollation(sortCollation)
+	if pageCount > 0 {
+		// one more than the page is read to know whether a next page exists
+		opts.SetLimit(int64(pageCount + 1))
+	}
 
 	cur, err := collection.Find(context.Background(), filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	defer cur.Close(context.Background())
 
 	matches := []model.Armor{}
+	next := ""
 
+	var last bson.Raw
 	for cur.Next(context.Background()) {
+		if pageCount > 0 && len(matches) == pageCount {
+			next, err = nextCursor(sort, last)
+			if err != nil {
+				return nil, "", err
+			}
+			break
+		}
+
 		elem := model.Armor{}
 		err := cur.Decode(&elem)
 		if err != nil {
-			return nil, err
+			return nil, "", err
+		}
+
+		if err := elem.NormalizeQualities(); err != nil {
+			logrus.Debugf("Armor %v has unrecognized qualities: %v", elem.ID.Hex(), err)
 		}
 
 		matches = append(matches, elem)
+		last = append(bson.Raw{}, cur.Current...)
+	}
+
+	return matches, next, cur.Err()
+}
+
+//CountArmor is the database implementation to count the armor objects matching the query, paging is ignored
+func (g *GearDB) CountArmor(queryParams url.Values) (int64, error) {
+	logrus.Debug("BEGIN - CountArmor")
+
+	collection := g.client.Database(g.databaseName).Collection(g.armorCollection)
+
+	queryParams, _, err := cursorQuery(queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	_, _, _, filter, err := armorQuery(queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	return collection.CountDocuments(context.Background(), filter, options.Count().SetMaxTime(30*time.Second).SetCollation(sortCollation))
+}
+
+// armorQuery builds the paging, sort and mongo filter for the armor query parameters
+func armorQuery(queryParams url.Values) (int, int, api.Sort, bson.M, error) {
+	queryParams, qualities, err := qualityFilter(queryParams)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	queryParams, legal, err := legalFilter(queryParams)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	queryParams, sources, err := sourceFilter(queryParams)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, armorFields)
+	if err != nil {
+		return 0, 0, nil, nil, err
 	}
 
-	return matches, nil
+	sort, err := api.ParseSort(sortParam, armorSortFields)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	return pageNumber, pageCount, sort, api.MergeFilters(filter, qualities, legal, sources), nil
 }
 
 //GetArmorByID is the database implementation to get a pspecific armor back from the database
@@ -101,15 +190,21 @@ func (g *GearDB) GetArmorByID(mongoID primitive.ObjectID) (*model.Armor, error)
 		return nil, err
 	}
 
+	if err := armor.NormalizeQualities(); err != nil {
+		logrus.Debugf("Armor %v has unrecognized qualities: %v", mongoID.Hex(), err)
+	}
+
 	return &armor, err
 }
 
-//UpdateArmorByID updates a specific armor in the armor database
+//UpdateArmorByID updates a specific armor in the armor database, the update is refused when the armor would have fewer hard
+//points than its installed attachments use
 func (g *GearDB) UpdateArmorByID(armor model.Armor, mongoID primitive.ObjectID) error {
 
 	collection := g.client.Database(g.databaseName).Collection(g.armorCollection)
 
-	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+	filter := hardPointsFilter(mongoID, armor.HardPoints, armor.Attachments)
+	result, err := collection.UpdateOne(context.Background(), filter, bson.D{{
 		Key:   "$set",
 		Value: armor,
 	}})
@@ -121,6 +216,9 @@ func (g *GearDB) UpdateArmorByID(armor model.Armor, mongoID primitive.ObjectID)
 	modified := strconv.FormatInt(result.ModifiedCount, 10)
 
 	if result.MatchedCount != 1 {
+		if err := checkHardPoints(collection, mongoID, "hardPoints", armor.HardPoints); err != nil {
+			return err
+		}
 		return errors.New("Could not update sheet. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
 	}
 
@@ -153,46 +251,170 @@ func (g *GearDB) InsertWeapon(weapon *model.Weapon) error {
 	return err
 }
 
-//GetWeapon is the database implementation to get all armor objects
-func (g *GearDB) GetWeapon(queryParams url.Values) ([]model.Weapon, error) {
+//GetWeapon is the database implementation to get all weapon objects, the next cursor is empty on the last page
+func (g *GearDB) GetWeapon(queryParams url.Values) ([]model.Weapon, string, error) {
 	logrus.Debug("BEGIN - GetWeapon")
 
 	collection := g.client.Database(g.databaseName).Collection(g.weaponCollection)
 
-	pageNumber, pageCount, sort, filter := api.BuildFilter(queryParams)
+	queryParams, after, err := cursorQuery(queryParams)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pipeline, pageNumber, pageCount, sort, err := weaponPipeline(queryParams)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the keyset is matched after the computed sort fields are added
+	keyset, err := after.filter(sort)
+	if err != nil {
+		return nil, "", err
+	}
+	if keyset != nil {
+		pipeline = append(pipeline, bson.M{"$match": keyset})
+	}
 
 	skip := 0
-	if pageNumber > 0 {
+	if pageNumber > 0 && after == nil {
 		skip = (pageNumber - 1) * pageCount
 	}
 
-	opts := options.Find().
+	pipeline = append(pipeline,
+		bson.M{"$sort": sort.Document()},
+		bson.M{"$skip": skip},
+	)
+	if pageCount > 0 {
+		// one more than the page is read to know whether a next page exists
+		pipeline = append(pipeline, bson.M{"$limit": pageCount + 1})
+	}
+
+	opts := options.Aggregate().
 		SetMaxTime(30 * time.Second).
-		SetSkip(int64(skip)).
-		SetLimit(int64(pageCount)).
-		SetSort(bson.D{{
-			Key:   sort,
-			Value: 1,
-		}})
+		SetCollation(sortCollation)
 
-	cur, err := collection.Find(context.Background(), filter, opts)
+	cur, err := collection.Aggregate(context.Background(), pipeline, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	defer cur.Close(context.Background())
 
 	matches := []model.Weapon{}
+	next := ""
 
+	var last bson.Raw
 	for cur.Next(context.Background()) {
+		if pageCount > 0 && len(matches) == pageCount {
+			next, err = nextCursor(sort, last)
+			if err != nil {
+				return nil, "", err
+			}
+			break
+		}
+
 		elem := model.Weapon{}
 		err := cur.Decode(&elem)
 		if err != nil {
-			return nil, err
+			return nil, "", err
+		}
+
+		if err := elem.NormalizeQualities(); err != nil {
+			logrus.Debugf("Weapon %v has unrecognized qualities: %v", elem.ID.Hex(), err)
 		}
 
 		matches = append(matches, elem)
+		last = append(bson.Raw{}, cur.Current...)
+	}
+
+	return matches, next, cur.Err()
+}
+
+//CountWeapon is the database implementation to count the weapon objects matching the query, paging is ignored
+func (g *GearDB) CountWeapon(queryParams url.Values) (int64, error) {
+	logrus.Debug("BEGIN - CountWeapon")
+
+	collection := g.client.Database(g.databaseName).Collection(g.weaponCollection)
+
+	queryParams, _, err := cursorQuery(queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	pipeline, _, _, _, err := weaponPipeline(queryParams)
+	if err != nil {
+		return 0, err
+	}
+	pipeline = append(pipeline, bson.M{"$count": "total"})
+
+	cur, err := collection.Aggregate(context.Background(), pipeline, options.Aggregate().SetMaxTime(30*time.Second).SetCollation(sortCollation))
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(context.Background())
+
+	count := struct {
+		Total int64 `bson:"total"`
+	}{}
+	if cur.Next(context.Background()) {
+		err = cur.Decode(&count)
+	}
+	if err != nil {
+		return 0, err
 	}
 
-	return matches, nil
+	return count.Total, cur.Err()
+}
+
+// weaponPipeline builds the aggregation stages selecting the weapons matching the query parameters, along with the paging
+// and the sort to apply to them. Damage filters and sorts use the effective damage for the requested Brawn.
+func weaponPipeline(queryParams url.Values) ([]bson.M, int, int, api.Sort, error) {
+	queryParams, qualities, err := qualityFilter(queryParams)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	queryParams, brawn, damage, err := damageQuery(queryParams)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	queryParams, ranges, err := rangeFilter(queryParams)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	queryParams, legal, err := legalFilter(queryParams)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	queryParams, sources, err := sourceFilter(queryParams)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, weaponFields)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+	filter = api.MergeFilters(filter, qualities, ranges, legal, sources)
+
+	sort, err := api.ParseSort(sortParam, weaponSortFields)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	pipeline := []bson.M{
+		{"$match": filter},
+		effectiveDamageStage(brawn),
+		rangeRankStage(),
+	}
+	if damage != nil {
+		pipeline = append(pipeline, bson.M{"$match": damage})
+	}
+
+	return pipeline, pageNumber, pageCount, sort, nil
 }
 
 //GetWeaponByID is the database implementation to get a specific weapon back from the database
@@ -209,15 +431,21 @@ func (g *GearDB) GetWeaponByID(mongoID primitive.ObjectID) (*model.Weapon, error
 		return nil, err
 	}
 
+	if err := weapon.NormalizeQualities(); err != nil {
+		logrus.Debugf("Weapon %v has unrecognized qualities: %v", mongoID.Hex(), err)
+	}
+
 	return &weapon, err
 }
 
-//UpdateWeaponByID updates a specific weapon in the weapon database
+//UpdateWeaponByID updates a specific weapon in the weapon database, the update is refused when the weapon would have fewer hard
+//points than its installed attachments use
 func (g *GearDB) UpdateWeaponByID(weapon model.Weapon, mongoID primitive.ObjectID) error {
 
 	collection := g.client.Database(g.databaseName).Collection(g.weaponCollection)
 
-	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+	filter := hardPointsFilter(mongoID, weapon.HP, weapon.Attachments)
+	result, err := collection.UpdateOne(context.Background(), filter, bson.D{{
 		Key:   "$set",
 		Value: weapon,
 	}})
@@ -229,6 +457,9 @@ func (g *GearDB) UpdateWeaponByID(weapon model.Weapon, mongoID primitive.ObjectI
 	modified := strconv.FormatInt(result.ModifiedCount, 10)
 
 	if result.MatchedCount != 1 {
+		if err := checkHardPoints(collection, mongoID, "hp", weapon.HP); err != nil {
+			return err
+		}
 		return errors.New("Could not update sheet. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
 	}
 
@@ -249,3 +480,34 @@ func (g *GearDB) DeleteWeaponByID(mongoID primitive.ObjectID) error {
 
 	return err
 }
+
+//MigrateWeaponDamage rewrites weapons still storing the legacy string damage into the numeric damage document so they can be sorted and filtered
+func (g *GearDB) MigrateWeaponDamage(ctx context.Context) (int64, error) {
+	logrus.Debug("BEGIN - MigrateWeaponDamage")
+
+	collection := g.client.Database(g.databaseName).Collection(g.weaponCollection)
+
+	cur, err := collection.Find(ctx, bson.M{"damage": bson.M{"$type": "string"}})
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	var migrated int64
+	for cur.Next(ctx) {
+		weapon := model.Weapon{}
+		err := cur.Decode(&weapon)
+		if err != nil {
+			logrus.Warnf("Skipping damage migration for weapon %v: %v", cur.Current.Lookup("_id"), err)
+			continue
+		}
+
+		_, err = collection.UpdateOne(ctx, bson.M{"_id": weapon.ID}, bson.M{"$set": bson.M{"damage": weapon.Damage}})
+		if err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, cur.Err()
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertGear is the database implementation to insert a gear object
+func (g *GearDB) InsertGear(gear *model.Gear) error {
+	logrus.Debug("BEGIN - InsertGear")
+
+	collection := g.client.Database(g.databaseName).Collection(g.gearCollection)
+
+	_, err := collection.InsertOne(context.Background(), gear)
+
+	return err
+}
+
+//GetGear is the database implementation to get all gear objects
+func (g *GearDB) GetGear(queryParams url.Values) ([]model.Gear, error) {
+	logrus.Debug("BEGIN - GetGear")
+
+	collection := g.client.Database(g.databaseName).Collection(g.gearCollection)
+
+	pageNumber, pageCount, sort, filter, err := gearQuery(queryParams)
+	if err != nil {
+		return nil, err
+	}
+	skip := 0
+	if pageNumber > 0 {
+		skip = (pageNumber - 1) * pageCount
+	}
+
+	opts := options.Find().
+		SetMaxTime(30 * time.Second).
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageCount)).
+		SetSort(sort.Document())
+
+	cur, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.Gear{}
+
+	for cur.Next(context.Background()) {
+		elem := model.Gear{}
+		err := cur.Decode(&elem)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, elem)
+	}
+
+	return matches, nil
+}
+
+//CountGear is the database implementation to count the gear matching the filters of a GetGear query
+func (g *GearDB) CountGear(queryParams url.Values) (int64, error) {
+	logrus.Debug("BEGIN - CountGear")
+
+	collection := g.client.Database(g.databaseName).Collection(g.gearCollection)
+
+	_, _, _, filter, err := gearQuery(queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	return collection.CountDocuments(context.Background(), filter, options.Count().SetMaxTime(30*time.Second))
+}
+
+// gearQuery reads the paging, sort and filters of a gear query
+func gearQuery(queryParams url.Values) (int, int, api.Sort, bson.M, error) {
+	queryParams, legal, err := legalFilter(queryParams)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, gearFields)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	sort, err := api.ParseSort(sortParam, gearSortFields)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	return pageNumber, pageCount, sort, api.MergeFilters(filter, legal), nil
+}
+
+//GetGearByID is the database implementation to get a specific gear back from the database
+func (g *GearDB) GetGearByID(mongoID primitive.ObjectID) (*model.Gear, error) {
+	logrus.Debugf("BEGIN - GetGearByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.gearCollection)
+	query := api.BuildQuery(&mongoID, nil)
+
+	gear := model.Gear{}
+
+	err := collection.FindOne(context.Background(), query).Decode(&gear)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gear, err
+}
+
+//UpdateGearByID updates a specific gear in the gear database
+func (g *GearDB) UpdateGearByID(gear model.Gear, mongoID primitive.ObjectID) error {
+
+	collection := g.client.Database(g.databaseName).Collection(g.gearCollection)
+
+	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+		Key:   "$set",
+		Value: gear,
+	}})
+	if err != nil {
+		return err
+	}
+
+	matched := strconv.FormatInt(result.MatchedCount, 10)
+	modified := strconv.FormatInt(result.ModifiedCount, 10)
+
+	if result.MatchedCount != 1 {
+		return errors.New("Could not update gear. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
+	}
+
+	if result.ModifiedCount != 1 {
+		return errors.New("Could not update gear. Tried to updated " + mongoID.Hex() + " tried to update " + modified + " number of results instead of 1")
+	}
+
+	return nil
+}
+
+//DeleteGearByID deletes a specific gear from the database
+func (g *GearDB) DeleteGearByID(mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - DeleteGearByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.gearCollection)
+
+	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+
+	return err
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertInstance is the database implementation to insert a item instance
+func (g *GearDB) InsertInstance(instance *model.ItemInstance) error {
+	logrus.Debug("BEGIN - InsertInstance")
+
+	collection := g.client.Database(g.databaseName).Collection(g.instanceCollection)
+
+	_, err := collection.InsertOne(context.Background(), instance)
+
+	return err
+}
+
+//GetInstance is the database implementation to get all item instances
+func (g *GearDB) GetInstance(queryParams url.Values) ([]model.ItemInstance, error) {
+	logrus.Debug("BEGIN - GetInstance")
+
+	collection := g.client.Database(g.databaseName).Collection(g.instanceCollection)
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, instanceFields)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := api.ParseSort(sortParam, instanceSortFields)
+	if err != nil {
+		return nil, err
+	}
+	skip := 0
+	if pageNumber > 0 {
+		skip = (pageNumber - 1) * pageCount
+	}
+
+	opts := options.Find().
+		SetMaxTime(30 * time.Second).
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageCount)).
+		SetSort(sort.Document())
+
+	cur, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.ItemInstance{}
+
+	for cur.Next(context.Background()) {
+		elem := model.ItemInstance{}
+		err := cur.Decode(&elem)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, elem)
+	}
+
+	return matches, nil
+}
+
+//GetInstanceByID is the database implementation to get a specific item instance back from the database
+func (g *GearDB) GetInstanceByID(mongoID primitive.ObjectID) (*model.ItemInstance, error) {
+	logrus.Debugf("BEGIN - GetInstanceByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.instanceCollection)
+	query := api.BuildQuery(&mongoID, nil)
+
+	instance := model.ItemInstance{}
+
+	err := collection.FindOne(context.Background(), query).Decode(&instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance, err
+}
+
+//UpdateInstanceByID updates a specific item instance in the instance database
+func (g *GearDB) UpdateInstanceByID(instance model.ItemInstance, mongoID primitive.ObjectID) error {
+
+	collection := g.client.Database(g.databaseName).Collection(g.instanceCollection)
+
+	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+		Key:   "$set",
+		Value: instance,
+	}})
+	if err != nil {
+		return err
+	}
+
+	matched := strconv.FormatInt(result.MatchedCount, 10)
+	modified := strconv.FormatInt(result.ModifiedCount, 10)
+
+	if result.MatchedCount != 1 {
+		return errors.New("Could not update item instance. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
+	}
+
+	if result.ModifiedCount != 1 {
+		return errors.New("Could not update item instance. Tried to updated " + mongoID.Hex() + " tried to update " + modified + " number of results instead of 1")
+	}
+
+	return nil
+}
+
+//CountAttachmentInstances counts the item instances with a specific attachment installed
+func (g *GearDB) CountAttachmentInstances(attachmentID primitive.ObjectID) (int64, error) {
+	logrus.Debugf("BEGIN - CountAttachmentInstances: %v", attachmentID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.instanceCollection)
+
+	return collection.CountDocuments(context.Background(), bson.M{"attachments.attachmentId": attachmentID}, options.Count().SetMaxTime(30*time.Second))
+}
+
+//DeleteInstanceByID deletes a specific item instance from the database
+func (g *GearDB) DeleteInstanceByID(mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - DeleteInstanceByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.instanceCollection)
+
+	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+
+	return err
+}
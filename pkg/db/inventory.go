@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//GetInventory is the database implementation to get the inventory of a character, characters without one get an empty inventory
+func (g *GearDB) GetInventory(characterID string) (*model.Inventory, error) {
+	logrus.Debugf("BEGIN - GetInventory: %v", characterID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.inventoryCollection)
+
+	inventory := model.Inventory{}
+
+	err := collection.FindOne(context.Background(), bson.M{"_id": characterID}).Decode(&inventory)
+	if err == mongo.ErrNoDocuments {
+		return &model.Inventory{CharacterID: characterID, Items: []model.InventoryItem{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory, nil
+}
+
+//UpdateInventory replaces the inventory of a character, creating it if it does not exist
+func (g *GearDB) UpdateInventory(inventory model.Inventory) error {
+	logrus.Debugf("BEGIN - UpdateInventory: %v", inventory.CharacterID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.inventoryCollection)
+
+	_, err := collection.ReplaceOne(context.Background(), bson.M{"_id": inventory.CharacterID}, inventory, options.Replace().SetUpsert(true))
+
+	return err
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertLootTable is the database implementation to insert a loot table
+func (g *GearDB) InsertLootTable(table *model.LootTable) error {
+	logrus.Debug("BEGIN - InsertLootTable")
+
+	collection := g.client.Database(g.databaseName).Collection(g.lootCollection)
+
+	_, err := collection.InsertOne(context.Background(), table)
+
+	return err
+}
+
+//GetLootTable is the database implementation to get all loot tables
+func (g *GearDB) GetLootTable(queryParams url.Values) ([]model.LootTable, error) {
+	logrus.Debug("BEGIN - GetLootTable")
+
+	collection := g.client.Database(g.databaseName).Collection(g.lootCollection)
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, lootTableFields)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := api.ParseSort(sortParam, lootTableSortFields)
+	if err != nil {
+		return nil, err
+	}
+	skip := 0
+	if pageNumber > 0 {
+		skip = (pageNumber - 1) * pageCount
+	}
+
+	opts := options.Find().
+		SetMaxTime(30 * time.Second).
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageCount)).
+		SetSort(sort.Document())
+
+	cur, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.LootTable{}
+
+	for cur.Next(context.Background()) {
+		elem := model.LootTable{}
+		err := cur.Decode(&elem)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, elem)
+	}
+
+	return matches, nil
+}
+
+//GetLootTableByID is the database implementation to get a specific loot table back from the database
+func (g *GearDB) GetLootTableByID(mongoID primitive.ObjectID) (*model.LootTable, error) {
+	logrus.Debugf("BEGIN - GetLootTableByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.lootCollection)
+	query := api.BuildQuery(&mongoID, nil)
+
+	table := model.LootTable{}
+
+	err := collection.FindOne(context.Background(), query).Decode(&table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &table, err
+}
+
+//UpdateLootTableByID updates a specific loot table in the loot database
+func (g *GearDB) UpdateLootTableByID(table model.LootTable, mongoID primitive.ObjectID) error {
+
+	collection := g.client.Database(g.databaseName).Collection(g.lootCollection)
+
+	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+		Key:   "$set",
+		Value: table,
+	}})
+	if err != nil {
+		return err
+	}
+
+	matched := strconv.FormatInt(result.MatchedCount, 10)
+	modified := strconv.FormatInt(result.ModifiedCount, 10)
+
+	if result.MatchedCount != 1 {
+		return errors.New("Could not update loot table. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
+	}
+
+	if result.ModifiedCount != 1 {
+		return errors.New("Could not update loot table. Tried to updated " + mongoID.Hex() + " tried to update " + modified + " number of results instead of 1")
+	}
+
+	return nil
+}
+
+//DeleteLootTableByID deletes a specific loot table from the database
+func (g *GearDB) DeleteLootTableByID(mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - DeleteLootTableByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.lootCollection)
+
+	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+
+	return err
+}
@@ -1,10 +1,14 @@
 package mocks
 
 import (
+	"fmt"
 	"net/url"
+	"strconv"
 
 	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 //MockGearDatabase is a mock struct for testing
@@ -14,25 +18,109 @@ type MockGearDatabase struct {
 	WeaponToReturn  *model.Weapon
 	WeaponsToReturn []model.Weapon
 	ErrorToReturn   error
+	//CountToReturn, when set, is returned by the Count methods, otherwise they count the documents to return
+	CountToReturn int64
+	//NextCursorToReturn is returned as the next cursor by GetArmor and GetWeapon
+	NextCursorToReturn string
+	//LastQuery records the query passed to the last GetArmor or GetWeapon call
+	LastQuery url.Values
+	//WeaponPages, when set, is served by GetWeapon one page per call, the cursor is the index of the page
+	WeaponPages [][]model.Weapon
+
+	//ArmorByID and WeaponByID, when set, are used by the ByID lookups so tests can reference several documents
+	ArmorByID  map[primitive.ObjectID]*model.Armor
+	WeaponByID map[primitive.ObjectID]*model.Weapon
+
+	AttachmentToReturn  *model.Attachment
+	AttachmentsToReturn []model.Attachment
+	//InstalledAttachments records the attachments passed to the last Update*Attachments call
+	InstalledAttachments []model.InstalledAttachment
+	//AttachmentsChanged makes the Update*Attachments calls fail as if another request changed the attachments first
+	AttachmentsChanged bool
+	//AttachmentItems is returned by CountAttachmentItems
+	AttachmentItems int64
+
+	GearToReturn  *model.Gear
+	GearsToReturn []model.Gear
+
+	VehicleToReturn  *model.Vehicle
+	VehiclesToReturn []model.Vehicle
+
+	InventoryToReturn *model.Inventory
+	//UpdatedInventory records the inventory passed to the last UpdateInventory call
+	UpdatedInventory *model.Inventory
+
+	SourceCountsToReturn []model.SourceCount
+
+	TemplateToReturn  *model.CraftingTemplate
+	TemplatesToReturn []model.CraftingTemplate
+	//InsertedWeapon and InsertedArmor record the documents passed to the last insert call
+	InsertedWeapon *model.Weapon
+	InsertedArmor  *model.Armor
+	//UpdatedWeapon and UpdatedArmor record the documents passed to the last update call
+	UpdatedWeapon *model.Weapon
+	UpdatedArmor  *model.Armor
+
+	ShopToReturn *model.Shop
+	//SavedShop records the shop passed to the last SaveShop call
+	SavedShop *model.Shop
+
+	//LootTableByID is used by GetLootTableByID so tests can nest tables
+	LootTableByID      map[primitive.ObjectID]*model.LootTable
+	LootTablesToReturn []model.LootTable
+	//InsertedLootTable records the table passed to the last InsertLootTable call
+	InsertedLootTable *model.LootTable
+
+	InstanceToReturn  *model.ItemInstance
+	InstancesToReturn []model.ItemInstance
+	//AttachmentByID, when set, is used by GetAttachmentByID so tests can reference several attachments
+	AttachmentByID map[primitive.ObjectID]*model.Attachment
+	//InsertedInstance and UpdatedInstance record the instance passed to the last insert or update call
+	InsertedInstance *model.ItemInstance
+	UpdatedInstance  *model.ItemInstance
+	//AttachmentInstances is returned by CountAttachmentInstances
+	AttachmentInstances int64
+
+	SearchResultsToReturn []model.SearchResult
+	//Searched records the query passed to the last Search call
+	Searched *model.SearchQuery
 }
 
 //InsertArmor is the mock method for testing
 func (db *MockGearDatabase) InsertArmor(armor *model.Armor) error {
+	db.InsertedArmor = armor
 	return db.ErrorToReturn
 }
 
 //GetArmor is the mock method for testing
-func (db *MockGearDatabase) GetArmor(query url.Values) ([]model.Armor, error) {
-	return db.ArmorsToReturn, db.ErrorToReturn
+func (db *MockGearDatabase) GetArmor(query url.Values) ([]model.Armor, string, error) {
+	db.LastQuery = query
+	return db.ArmorsToReturn, db.NextCursorToReturn, db.ErrorToReturn
+}
+
+//CountArmor is the mock method for testing
+func (db *MockGearDatabase) CountArmor(query url.Values) (int64, error) {
+	if db.CountToReturn != 0 {
+		return db.CountToReturn, db.ErrorToReturn
+	}
+	return int64(len(db.ArmorsToReturn)), db.ErrorToReturn
 }
 
 //GetArmorByID is the mock method for testing
 func (db *MockGearDatabase) GetArmorByID(mongoID primitive.ObjectID) (*model.Armor, error) {
+	if db.ArmorByID != nil {
+		armor, ok := db.ArmorByID[mongoID]
+		if !ok {
+			return nil, mongo.ErrNoDocuments
+		}
+		return armor, db.ErrorToReturn
+	}
 	return db.ArmorToReturn, db.ErrorToReturn
 }
 
 //UpdateArmorByID is the mock method for testing
 func (db *MockGearDatabase) UpdateArmorByID(armor model.Armor, mongoID primitive.ObjectID) error {
+	db.UpdatedArmor = &armor
 	return db.ErrorToReturn
 }
 
@@ -43,21 +131,47 @@ func (db *MockGearDatabase) DeleteArmorByID(mongoID primitive.ObjectID) error {
 
 //InsertWeapon is the mock method for testing
 func (db *MockGearDatabase) InsertWeapon(weapon *model.Weapon) error {
+	db.InsertedWeapon = weapon
 	return db.ErrorToReturn
 }
 
 //GetWeapon is the mock method for testing
-func (db *MockGearDatabase) GetWeapon(query url.Values) ([]model.Weapon, error) {
-	return db.WeaponsToReturn, db.ErrorToReturn
+func (db *MockGearDatabase) GetWeapon(query url.Values) ([]model.Weapon, string, error) {
+	db.LastQuery = query
+	if db.WeaponPages != nil {
+		page, _ := strconv.Atoi(query.Get("cursor"))
+		next := ""
+		if page+1 < len(db.WeaponPages) {
+			next = strconv.Itoa(page + 1)
+		}
+		return db.WeaponPages[page], next, db.ErrorToReturn
+	}
+	return db.WeaponsToReturn, db.NextCursorToReturn, db.ErrorToReturn
+}
+
+//CountWeapon is the mock method for testing
+func (db *MockGearDatabase) CountWeapon(query url.Values) (int64, error) {
+	if db.CountToReturn != 0 {
+		return db.CountToReturn, db.ErrorToReturn
+	}
+	return int64(len(db.WeaponsToReturn)), db.ErrorToReturn
 }
 
 //GetWeaponByID is the mock method for testing
 func (db *MockGearDatabase) GetWeaponByID(mongoID primitive.ObjectID) (*model.Weapon, error) {
+	if db.WeaponByID != nil {
+		weapon, ok := db.WeaponByID[mongoID]
+		if !ok {
+			return nil, mongo.ErrNoDocuments
+		}
+		return weapon, db.ErrorToReturn
+	}
 	return db.WeaponToReturn, db.ErrorToReturn
 }
 
 //UpdateWeaponByID is the mock method for testing
 func (db *MockGearDatabase) UpdateWeaponByID(weapon model.Weapon, mongoID primitive.ObjectID) error {
+	db.UpdatedWeapon = &weapon
 	return db.ErrorToReturn
 }
 
@@ -66,7 +180,248 @@ func (db *MockGearDatabase) DeleteWeaponByID(mongoID primitive.ObjectID) error {
 	return db.ErrorToReturn
 }
 
+//InsertAttachment is the mock method for testing
+func (db *MockGearDatabase) InsertAttachment(attachment *model.Attachment) error {
+	return db.ErrorToReturn
+}
+
+//GetAttachment is the mock method for testing
+func (db *MockGearDatabase) GetAttachment(query url.Values) ([]model.Attachment, error) {
+	return db.AttachmentsToReturn, db.ErrorToReturn
+}
+
+//GetAttachmentByID is the mock method for testing
+func (db *MockGearDatabase) GetAttachmentByID(mongoID primitive.ObjectID) (*model.Attachment, error) {
+	if db.AttachmentByID != nil {
+		attachment, ok := db.AttachmentByID[mongoID]
+		if !ok {
+			return nil, mongo.ErrNoDocuments
+		}
+		return attachment, db.ErrorToReturn
+	}
+	return db.AttachmentToReturn, db.ErrorToReturn
+}
+
+//UpdateAttachmentByID is the mock method for testing
+func (db *MockGearDatabase) UpdateAttachmentByID(attachment model.Attachment, mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//DeleteAttachmentByID is the mock method for testing
+func (db *MockGearDatabase) DeleteAttachmentByID(mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//UpdateArmorAttachments is the mock method for testing
+func (db *MockGearDatabase) UpdateArmorAttachments(attachments, prior []model.InstalledAttachment, mongoID primitive.ObjectID) error {
+	return db.updateAttachments(attachments)
+}
+
+//UpdateWeaponAttachments is the mock method for testing
+func (db *MockGearDatabase) UpdateWeaponAttachments(attachments, prior []model.InstalledAttachment, mongoID primitive.ObjectID) error {
+	return db.updateAttachments(attachments)
+}
+
+//CountAttachmentItems is the mock method for testing
+func (db *MockGearDatabase) CountAttachmentItems(attachmentID primitive.ObjectID) (int64, error) {
+	return db.AttachmentItems, db.ErrorToReturn
+}
+
+func (db *MockGearDatabase) updateAttachments(attachments []model.InstalledAttachment) error {
+	if db.AttachmentsChanged {
+		return fmt.Errorf("attachments changed: %w", api.ErrConflict)
+	}
+	db.InstalledAttachments = attachments
+	return db.ErrorToReturn
+}
+
+//InsertGear is the mock method for testing
+func (db *MockGearDatabase) InsertGear(gear *model.Gear) error {
+	return db.ErrorToReturn
+}
+
+//GetGear is the mock method for testing
+func (db *MockGearDatabase) GetGear(query url.Values) ([]model.Gear, error) {
+	return db.GearsToReturn, db.ErrorToReturn
+}
+
+//CountGear is the mock method for testing
+func (db *MockGearDatabase) CountGear(query url.Values) (int64, error) {
+	if db.CountToReturn != 0 {
+		return db.CountToReturn, db.ErrorToReturn
+	}
+	return int64(len(db.GearsToReturn)), db.ErrorToReturn
+}
+
+//GetGearByID is the mock method for testing
+func (db *MockGearDatabase) GetGearByID(mongoID primitive.ObjectID) (*model.Gear, error) {
+	return db.GearToReturn, db.ErrorToReturn
+}
+
+//UpdateGearByID is the mock method for testing
+func (db *MockGearDatabase) UpdateGearByID(gear model.Gear, mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//DeleteGearByID is the mock method for testing
+func (db *MockGearDatabase) DeleteGearByID(mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//InsertVehicle is the mock method for testing
+func (db *MockGearDatabase) InsertVehicle(vehicle *model.Vehicle) error {
+	return db.ErrorToReturn
+}
+
+//GetVehicle is the mock method for testing
+func (db *MockGearDatabase) GetVehicle(query url.Values) ([]model.Vehicle, error) {
+	return db.VehiclesToReturn, db.ErrorToReturn
+}
+
+//GetVehicleByID is the mock method for testing
+func (db *MockGearDatabase) GetVehicleByID(mongoID primitive.ObjectID) (*model.Vehicle, error) {
+	return db.VehicleToReturn, db.ErrorToReturn
+}
+
+//UpdateVehicleByID is the mock method for testing
+func (db *MockGearDatabase) UpdateVehicleByID(vehicle model.Vehicle, mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//DeleteVehicleByID is the mock method for testing
+func (db *MockGearDatabase) DeleteVehicleByID(mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//GetInventory is the mock method for testing
+func (db *MockGearDatabase) GetInventory(characterID string) (*model.Inventory, error) {
+	return db.InventoryToReturn, db.ErrorToReturn
+}
+
+//UpdateInventory is the mock method for testing
+func (db *MockGearDatabase) UpdateInventory(inventory model.Inventory) error {
+	db.UpdatedInventory = &inventory
+	return db.ErrorToReturn
+}
+
 //Ping is the mock implementation for testing
 func (db *MockGearDatabase) Ping() error {
 	return db.ErrorToReturn
 }
+
+//GetSourceCounts is the mock method for testing
+func (db *MockGearDatabase) GetSourceCounts() ([]model.SourceCount, error) {
+	return db.SourceCountsToReturn, db.ErrorToReturn
+}
+
+//InsertTemplate is the mock method for testing
+func (db *MockGearDatabase) InsertTemplate(template *model.CraftingTemplate) error {
+	return db.ErrorToReturn
+}
+
+//GetTemplate is the mock method for testing
+func (db *MockGearDatabase) GetTemplate(query url.Values) ([]model.CraftingTemplate, error) {
+	return db.TemplatesToReturn, db.ErrorToReturn
+}
+
+//GetTemplateByID is the mock method for testing
+func (db *MockGearDatabase) GetTemplateByID(mongoID primitive.ObjectID) (*model.CraftingTemplate, error) {
+	return db.TemplateToReturn, db.ErrorToReturn
+}
+
+//UpdateTemplateByID is the mock method for testing
+func (db *MockGearDatabase) UpdateTemplateByID(template model.CraftingTemplate, mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//DeleteTemplateByID is the mock method for testing
+func (db *MockGearDatabase) DeleteTemplateByID(mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//GetShopByID is the mock method for testing
+func (db *MockGearDatabase) GetShopByID(mongoID primitive.ObjectID) (*model.Shop, error) {
+	return db.ShopToReturn, db.ErrorToReturn
+}
+
+//SaveShop is the mock method for testing
+func (db *MockGearDatabase) SaveShop(shop model.Shop) error {
+	db.SavedShop = &shop
+	return db.ErrorToReturn
+}
+
+//DeleteShopByID is the mock method for testing
+func (db *MockGearDatabase) DeleteShopByID(mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//InsertLootTable is the mock method for testing
+func (db *MockGearDatabase) InsertLootTable(table *model.LootTable) error {
+	db.InsertedLootTable = table
+	return db.ErrorToReturn
+}
+
+//GetLootTable is the mock method for testing
+func (db *MockGearDatabase) GetLootTable(query url.Values) ([]model.LootTable, error) {
+	return db.LootTablesToReturn, db.ErrorToReturn
+}
+
+//GetLootTableByID is the mock method for testing
+func (db *MockGearDatabase) GetLootTableByID(mongoID primitive.ObjectID) (*model.LootTable, error) {
+	table, ok := db.LootTableByID[mongoID]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return table, db.ErrorToReturn
+}
+
+//UpdateLootTableByID is the mock method for testing
+func (db *MockGearDatabase) UpdateLootTableByID(table model.LootTable, mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//DeleteLootTableByID is the mock method for testing
+func (db *MockGearDatabase) DeleteLootTableByID(mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//InsertInstance is the mock method for testing
+func (db *MockGearDatabase) InsertInstance(instance *model.ItemInstance) error {
+	db.InsertedInstance = instance
+	return db.ErrorToReturn
+}
+
+//GetInstance is the mock method for testing
+func (db *MockGearDatabase) GetInstance(query url.Values) ([]model.ItemInstance, error) {
+	return db.InstancesToReturn, db.ErrorToReturn
+}
+
+//GetInstanceByID is the mock method for testing
+func (db *MockGearDatabase) GetInstanceByID(mongoID primitive.ObjectID) (*model.ItemInstance, error) {
+	return db.InstanceToReturn, db.ErrorToReturn
+}
+
+//UpdateInstanceByID is the mock method for testing
+func (db *MockGearDatabase) UpdateInstanceByID(instance model.ItemInstance, mongoID primitive.ObjectID) error {
+	db.UpdatedInstance = &instance
+	return db.ErrorToReturn
+}
+
+//CountAttachmentInstances is the mock method for testing
+func (db *MockGearDatabase) CountAttachmentInstances(attachmentID primitive.ObjectID) (int64, error) {
+	return db.AttachmentInstances, db.ErrorToReturn
+}
+
+//DeleteInstanceByID is the mock method for testing
+func (db *MockGearDatabase) DeleteInstanceByID(mongoID primitive.ObjectID) error {
+	return db.ErrorToReturn
+}
+
+//Search is the mock method for testing
+func (db *MockGearDatabase) Search(search model.SearchQuery, pageNumber, pageCount int) ([]model.SearchResult, int64, error) {
+	db.Searched = &search
+	if db.CountToReturn != 0 {
+		return db.SearchResultsToReturn, db.CountToReturn, db.ErrorToReturn
+	}
+	return db.SearchResultsToReturn, int64(len(db.SearchResultsToReturn)), db.ErrorToReturn
+}
@@ -0,0 +1,399 @@
+package db
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	qualityParam       = "quality"
+	qualityRatingParam = "qualityRating"
+	damageParam        = "damage"
+	brawnParam         = "brawn"
+	effectiveDamage    = "effectiveDamage"
+	minRangeParam      = "minRange"
+	maxRangeParam      = "maxRange"
+	rangeParam         = "range"
+	rangeRank          = "rangeRank"
+	legalParam         = "legal"
+	sourceParam        = "source"
+	gameLineParam      = "gameLine"
+)
+
+// The filterable fields of every collection, read from the stored models
+var (
+	armorFields      = api.ModelFields(model.Armor{})
+	weaponFields     = api.ModelFields(model.Weapon{})
+	attachmentFields = api.ModelFields(model.Attachment{})
+	gearFields       = api.ModelFields(model.Gear{})
+	vehicleFields    = api.ModelFields(model.Vehicle{})
+	templateFields   = api.ModelFields(model.CraftingTemplate{})
+	lootTableFields  = api.ModelFields(model.LootTable{})
+	instanceFields   = api.ModelFields(model.ItemInstance{})
+)
+
+// The fields armor and weapons can be sorted on, keyed by the name used in the sort parameter. Weapons sort damage on the
+// effective damage and range on the range band order rather than the stored values.
+var (
+	armorSortFields = map[string]string{
+		"_id":         "_id",
+		"type":        "type",
+		"defense":     "defense",
+		"soak":        "soak",
+		"price":       "price",
+		"encumbrance": "encumbrance",
+		"hardPoints":  "hardPoints",
+		"rarity":      "rarity",
+		"restricted":  "restricted",
+	}
+	weaponSortFields = map[string]string{
+		"_id":          "_id",
+		"type":         "type",
+		"name":         "name",
+		"skill":        "skill",
+		"damage":       effectiveDamage,
+		"critical":     "critical",
+		"range":        rangeRank,
+		"encumberence": "encumberence",
+		"hp":           "hp",
+		"price":        "price",
+		"rarity":       "rarity",
+		"restricted":   "restricted",
+	}
+)
+
+// The fields the other collections can be sorted on, keyed by the name used in the sort parameter
+var (
+	gearSortFields = map[string]string{
+		"_id":         "_id",
+		"name":        "name",
+		"category":    "category",
+		"price":       "price",
+		"encumbrance": "encumbrance",
+		"rarity":      "rarity",
+		"restricted":  "restricted",
+		"uses":        "uses",
+	}
+	attachmentSortFields = map[string]string{
+		"_id":        "_id",
+		"name":       "name",
+		"hardPoints": "hardPoints",
+		"price":      "price",
+		"rarity":     "rarity",
+		"restricted": "restricted",
+	}
+	vehicleSortFields = map[string]string{
+		"_id":              "_id",
+		"type":             "type",
+		"name":             "name",
+		"silhouette":       "silhouette",
+		"speed":            "speed",
+		"handling":         "handling",
+		"armor":            "armor",
+		"hullTrauma":       "hullTrauma",
+		"systemStrain":     "systemStrain",
+		"hardPoints":       "hardPoints",
+		"crew":             "crew",
+		"passengers":       "passengers",
+		"cargoEncumbrance": "cargoEncumbrance",
+		"price":            "price",
+		"rarity":           "rarity",
+		"restricted":       "restricted",
+	}
+	templateSortFields = map[string]string{
+		"_id":           "_id",
+		"name":          "name",
+		"kind":          "kind",
+		"materialPrice": "materialPrice",
+		"rarity":        "rarity",
+		"restricted":    "restricted",
+		"difficulty":    "difficulty",
+		"timeHours":     "timeHours",
+	}
+	lootTableSortFields = map[string]string{
+		"_id":   "_id",
+		"name":  "name",
+		"rolls": "rolls",
+	}
+	instanceSortFields = map[string]string{
+		"_id":         "_id",
+		"characterId": "characterId",
+		"kind":        "kind",
+		"itemId":      "itemId",
+		"customName":  "customName",
+	}
+)
+
+// sortCollation compares strings case-insensitively so "blaster" and "Blaster" sort together. The same collation is used
+// to match the filters, keeping equality filters and cursors consistent with the sort order.
+var sortCollation = &options.Collation{Locale: "en", Strength: 2}
+
+var comparisonOperators = map[string]string{
+	"eq":  "$eq",
+	"gt":  "$gt",
+	"gte": "$gte",
+	"lt":  "$lt",
+	"lte": "$lte",
+}
+
+// cloneValues copies the query parameters so consumed keys can be removed without touching the request
+func cloneValues(queryParams url.Values) url.Values {
+	clone := url.Values{}
+	for key, values := range queryParams {
+		clone[key] = append([]string{}, values...)
+	}
+	return clone
+}
+
+// numericComparison consumes the field and field[op] query parameters and returns the mongo comparison they describe
+func numericComparison(remaining url.Values, field string, validationErr *model.ValidationError) bson.M {
+	comparison := bson.M{}
+
+	for queryParam, paramValue := range remaining {
+		paramField, operator := api.SplitOperator(queryParam)
+		if paramField != field {
+			continue
+		}
+		delete(remaining, queryParam)
+
+		if operator == "" {
+			operator = "eq"
+		}
+		mongoOperator, ok := comparisonOperators[operator]
+		if !ok {
+			validationErr.Add(queryParam, fmt.Sprintf("unsupported operator %v", operator))
+			continue
+		}
+		value, err := strconv.ParseInt(paramValue[0], 10, 64)
+		if err != nil {
+			validationErr.Add(queryParam, fmt.Sprintf("%v is not a number", paramValue[0]))
+			continue
+		}
+		comparison[mongoOperator] = value
+	}
+
+	return comparison
+}
+
+// qualityFilter builds the mongo filter for the quality and qualityRating[op] query parameters.
+// The consumed parameters are removed from the returned values so they are not treated as equality filters.
+// Documents written before qualities existed are matched on their special string when no rating is requested.
+func qualityFilter(queryParams url.Values) (url.Values, bson.M, error) {
+	remaining := cloneValues(queryParams)
+	validationErr := model.ValidationError{}
+
+	names := remaining[qualityParam]
+	delete(remaining, qualityParam)
+
+	rating := numericComparison(remaining, qualityRatingParam, &validationErr)
+
+	if len(rating) > 0 && len(names) != 1 {
+		validationErr.Add(qualityRatingParam, "requires exactly one quality parameter")
+	}
+
+	conditions := []bson.M{}
+	for _, name := range names {
+		definition, ok := model.LookupQuality(name)
+		if !ok {
+			validationErr.Add(qualityParam, fmt.Sprintf("unknown quality %v", name))
+			continue
+		}
+
+		match := bson.M{"name": definition.Name}
+		if len(rating) > 0 {
+			match["rating"] = rating
+			conditions = append(conditions, bson.M{"qualities": bson.M{"$elemMatch": match}})
+			continue
+		}
+
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"qualities": bson.M{"$elemMatch": match}},
+			{
+				"qualities": bson.M{"$exists": false},
+				"special":   bson.M{"$regex": legacyQualityPattern(definition.Name), "$options": "i"},
+			},
+		}})
+	}
+
+	if len(validationErr) > 0 {
+		return nil, nil, validationErr
+	}
+	if len(conditions) == 0 {
+		return remaining, nil, nil
+	}
+
+	return remaining, bson.M{"$and": conditions}, nil
+}
+
+// legacyQualityPattern matches a quality in a legacy special string. The name must be a whole entry, optionally followed by
+// its rating, so Stun does not match Stun Setting.
+func legacyQualityPattern(name string) string {
+	return `(^|[,;])\s*` + regexp.QuoteMeta(name) + `(\s+\d+)?\s*([,;]|$)`
+}
+
+// damageQuery consumes the brawn and damage[op] query parameters. It returns the wielder's Brawn,
+// which is zero when not given, and the filter to apply to the computed effective damage.
+func damageQuery(queryParams url.Values) (url.Values, int64, bson.M, error) {
+	remaining := cloneValues(queryParams)
+	validationErr := model.ValidationError{}
+
+	var brawn int64
+	if paramValue, ok := remaining[brawnParam]; ok {
+		delete(remaining, brawnParam)
+		value, err := strconv.ParseInt(paramValue[0], 10, 64)
+		if err != nil || value < 0 {
+			validationErr.Add(brawnParam, fmt.Sprintf("%v is not a valid Brawn value", paramValue[0]))
+		}
+		brawn = value
+	}
+
+	comparison := numericComparison(remaining, damageParam, &validationErr)
+	if len(validationErr) > 0 {
+		return nil, 0, nil, validationErr
+	}
+	if len(comparison) == 0 {
+		return remaining, brawn, nil, nil
+	}
+
+	return remaining, brawn, bson.M{effectiveDamage: comparison}, nil
+}
+
+// effectiveDamageStage adds the effective damage for a wielder with the given Brawn to each weapon.
+// Documents still holding the legacy string damage have no base and sort before every migrated document.
+func effectiveDamageStage(brawn int64) bson.M {
+	return bson.M{"$addFields": bson.M{
+		effectiveDamage: bson.M{"$add": []interface{}{
+			"$damage.base",
+			bson.M{"$cond": []interface{}{"$damage.brawnRelative", brawn, 0}},
+		}},
+	}}
+}
+
+// rangeFilter consumes the minRange and maxRange query parameters and returns the range bands a weapon must be in
+func rangeFilter(queryParams url.Values) (url.Values, bson.M, error) {
+	remaining := cloneValues(queryParams)
+	validationErr := model.ValidationError{}
+	conditions := []bson.M{}
+
+	bounds := map[string]func(model.RangeBand) []model.RangeBand{
+		minRangeParam: model.RangeBand.AtLeast,
+		maxRangeParam: model.RangeBand.AtMost,
+	}
+	for queryParam, bands := range bounds {
+		paramValue, ok := remaining[queryParam]
+		if !ok {
+			continue
+		}
+		delete(remaining, queryParam)
+
+		band, err := model.ParseRangeBand(paramValue[0])
+		if err != nil {
+			validationErr.Add(queryParam, err.Error())
+			continue
+		}
+		conditions = append(conditions, bson.M{rangeParam: bson.M{"$in": bands(band)}})
+	}
+
+	if len(validationErr) > 0 {
+		return nil, nil, validationErr
+	}
+	if len(conditions) == 0 {
+		return remaining, nil, nil
+	}
+
+	return remaining, bson.M{"$and": conditions}, nil
+}
+
+// rangeRankStage adds the position of each weapon's range band so weapons sort from closest to farthest
+func rangeRankStage() bson.M {
+	return bson.M{"$addFields": bson.M{
+		rangeRank: bson.M{"$indexOfArray": []interface{}{model.RangeBands, "$" + rangeParam}},
+	}}
+}
+
+// legalFilter consumes the legal query parameter, legal=true hides restricted items and legal=false returns only restricted items
+func legalFilter(queryParams url.Values) (url.Values, bson.M, error) {
+	remaining := cloneValues(queryParams)
+
+	paramValue, ok := remaining[legalParam]
+	if !ok {
+		return remaining, nil, nil
+	}
+	delete(remaining, legalParam)
+
+	legal, err := strconv.ParseBool(paramValue[0])
+	if err != nil {
+		return nil, nil, model.ValidationError{{Field: legalParam, Message: fmt.Sprintf("%v is not true or false", paramValue[0])}}
+	}
+
+	if legal {
+		return remaining, bson.M{"restricted": bson.M{"$ne": true}}, nil
+	}
+	return remaining, bson.M{"restricted": true}, nil
+}
+
+// sourceFilter consumes the source and gameLine query parameters, both accept a comma separated list.
+// Items written without a game line match through the game line of their source books.
+func sourceFilter(queryParams url.Values) (url.Values, bson.M, error) {
+	remaining := cloneValues(queryParams)
+	validationErr := model.ValidationError{}
+	conditions := []bson.M{}
+
+	if paramValue, ok := remaining[sourceParam]; ok {
+		delete(remaining, sourceParam)
+
+		books := []string{}
+		for _, code := range strings.Split(paramValue[0], ",") {
+			book, ok := model.LookupSource(code)
+			if !ok {
+				validationErr.Add(sourceParam, fmt.Sprintf("unknown source book %v", strings.TrimSpace(code)))
+				continue
+			}
+			books = append(books, book.Code)
+		}
+		conditions = append(conditions, bson.M{"sources.book": bson.M{"$in": books}})
+	}
+
+	if paramValue, ok := remaining[gameLineParam]; ok {
+		delete(remaining, gameLineParam)
+
+		gameLines := []model.GameLine{}
+		books := []string{}
+		for _, name := range strings.Split(paramValue[0], ",") {
+			gameLine, err := model.ParseGameLine(name)
+			if err != nil {
+				validationErr.Add(gameLineParam, err.Error())
+				continue
+			}
+			gameLines = append(gameLines, gameLine)
+			for _, book := range model.SourceBooks() {
+				if book.GameLine == gameLine {
+					books = append(books, book.Code)
+				}
+			}
+		}
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{gameLineParam: bson.M{"$in": gameLines}},
+			{gameLineParam: bson.M{"$exists": false}, "sources.book": bson.M{"$in": books}},
+		}})
+	}
+
+	if len(validationErr) > 0 {
+		return nil, nil, validationErr
+	}
+	if len(conditions) == 0 {
+		return remaining, nil, nil
+	}
+	if len(conditions) == 1 {
+		return remaining, conditions[0], nil
+	}
+	return remaining, bson.M{"$and": conditions}, nil
+}
@@ -0,0 +1,234 @@
+package db
+
+import (
+	"net/url"
+	"reflect"
+	"regexp"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_qualityFilter_Rating(t *testing.T) {
+	queryParams := url.Values{"quality": {"pierce"}, "qualityRating[gte]": {"2"}, "name": {"Blaster"}}
+
+	remaining, filter, err := qualityFilter(queryParams)
+	if err != nil {
+		t.Fatalf("qualityFilter() error:\ngot: %v\nexpected: <nil>", err)
+	}
+
+	expected := bson.M{"$and": []bson.M{
+		{"qualities": bson.M{"$elemMatch": bson.M{"name": "Pierce", "rating": bson.M{"$gte": int64(2)}}}},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("qualityFilter() error:\ngot: %v\nexpected: %v", filter, expected)
+	}
+	if len(remaining) != 1 || remaining.Get("name") != "Blaster" {
+		t.Errorf("qualityFilter() error:\ngot: %v\nexpected only name to remain", remaining)
+	}
+	if len(queryParams) != 3 {
+		t.Errorf("qualityFilter() error: modified the request query parameters %v", queryParams)
+	}
+}
+
+func Test_qualityFilter_NameOnly(t *testing.T) {
+	_, filter, err := qualityFilter(url.Values{"quality": {"Stun Damage"}})
+	if err != nil {
+		t.Fatalf("qualityFilter() error:\ngot: %v\nexpected: <nil>", err)
+	}
+
+	conditions := filter["$and"].([]bson.M)
+	if len(conditions) != 1 || conditions[0]["$or"] == nil {
+		t.Errorf("qualityFilter() error:\ngot: %v\nexpected legacy special fallback", filter)
+	}
+}
+
+func Test_legacyQualityPattern(t *testing.T) {
+	stun := regexp.MustCompile("(?i)" + legacyQualityPattern("Stun"))
+	for special, expected := range map[string]bool{
+		"Stun":                  true,
+		"stun 2":                true,
+		"Pierce 2, Stun 1":      true,
+		"Stun; Vicious 1":       true,
+		"Stun Setting":          false,
+		"Pierce 2, Stun Damage": false,
+		"Stunning":              false,
+	} {
+		if stun.MatchString(special) != expected {
+			t.Errorf("legacyQualityPattern() error:\ngot: %v for %v\nexpected: %v", !expected, special, expected)
+		}
+	}
+}
+
+func Test_qualityFilter_None(t *testing.T) {
+	remaining, filter, err := qualityFilter(url.Values{"name": {"Blaster"}})
+	if err != nil || filter != nil || remaining.Get("name") != "Blaster" {
+		t.Errorf("qualityFilter() error:\ngot: %v, %v, %v\nexpected: untouched params, nil, nil", remaining, filter, err)
+	}
+}
+
+func Test_qualityFilter_Invalid(t *testing.T) {
+	invalid := []url.Values{
+		{"quality": {"Sparkly"}},
+		{"quality": {"Pierce"}, "qualityRating[gte]": {"two"}},
+		{"quality": {"Pierce"}, "qualityRating[near]": {"2"}},
+		{"qualityRating": {"2"}},
+	}
+
+	for _, queryParams := range invalid {
+		_, _, err := qualityFilter(queryParams)
+		if err == nil {
+			t.Errorf("qualityFilter(%v) error:\ngot: <nil>\nexpected: validation error", queryParams)
+		}
+	}
+}
+
+func Test_damageQuery(t *testing.T) {
+	remaining, brawn, filter, err := damageQuery(url.Values{"brawn": {"3"}, "damage[gte]": {"5"}, "name": {"Vibroknife"}})
+	if err != nil {
+		t.Fatalf("damageQuery() error:\ngot: %v\nexpected: <nil>", err)
+	}
+
+	expected := bson.M{"effectiveDamage": bson.M{"$gte": int64(5)}}
+	if brawn != 3 || !reflect.DeepEqual(filter, expected) {
+		t.Errorf("damageQuery() error:\ngot: %v, %v\nexpected: 3, %v", brawn, filter, expected)
+	}
+	if len(remaining) != 1 || remaining.Get("name") != "Vibroknife" {
+		t.Errorf("damageQuery() error:\ngot: %v\nexpected only name to remain", remaining)
+	}
+}
+
+func Test_damageQuery_Invalid(t *testing.T) {
+	invalid := []url.Values{
+		{"brawn": {"-1"}},
+		{"brawn": {"strong"}},
+		{"damage[gte]": {"lots"}},
+	}
+
+	for _, queryParams := range invalid {
+		_, _, _, err := damageQuery(queryParams)
+		if err == nil {
+			t.Errorf("damageQuery(%v) error:\ngot: <nil>\nexpected: validation error", queryParams)
+		}
+	}
+}
+
+func Test_rangeFilter(t *testing.T) {
+	remaining, filter, err := rangeFilter(url.Values{"minRange": {"medium"}, "name": {"Blaster Rifle"}})
+	if err != nil {
+		t.Fatalf("rangeFilter() error:\ngot: %v\nexpected: <nil>", err)
+	}
+
+	expected := bson.M{"$and": []bson.M{
+		{"range": bson.M{"$in": []model.RangeBand{model.Medium, model.Long, model.Extreme}}},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("rangeFilter() error:\ngot: %v\nexpected: %v", filter, expected)
+	}
+	if len(remaining) != 1 || remaining.Get("name") != "Blaster Rifle" {
+		t.Errorf("rangeFilter() error:\ngot: %v\nexpected only name to remain", remaining)
+	}
+}
+
+func Test_rangeFilter_Invalid(t *testing.T) {
+	_, _, err := rangeFilter(url.Values{"minRange": {"Planetary"}})
+	if err == nil {
+		t.Errorf("rangeFilter() error:\ngot: <nil>\nexpected: validation error")
+	}
+}
+
+func Test_legalFilter(t *testing.T) {
+	_, filter, err := legalFilter(url.Values{"legal": {"true"}})
+	if err != nil || !reflect.DeepEqual(filter, bson.M{"restricted": bson.M{"$ne": true}}) {
+		t.Errorf("legalFilter() error:\ngot: %v, %v\nexpected unrestricted filter", filter, err)
+	}
+
+	_, filter, err = legalFilter(url.Values{"legal": {"false"}})
+	if err != nil || !reflect.DeepEqual(filter, bson.M{"restricted": true}) {
+		t.Errorf("legalFilter() error:\ngot: %v, %v\nexpected restricted filter", filter, err)
+	}
+
+	if _, _, err := legalFilter(url.Values{"legal": {"maybe"}}); err == nil {
+		t.Errorf("legalFilter() error:\ngot: <nil>\nexpected: validation error")
+	}
+}
+
+func Test_sourceFilter(t *testing.T) {
+	remaining, filter, err := sourceFilter(url.Values{"source": {"eote-crb,AoR-CRB"}, "name": {"Blaster Rifle"}})
+	expected := bson.M{"sources.book": bson.M{"$in": []string{"EotE-CRB", "AoR-CRB"}}}
+	if err != nil || !reflect.DeepEqual(filter, expected) {
+		t.Errorf("sourceFilter() error:\ngot: %v, %v\nexpected: %v", filter, err, expected)
+	}
+	if len(remaining) != 1 || remaining.Get("name") != "Blaster Rifle" {
+		t.Errorf("sourceFilter() error:\ngot: %v\nexpected only name to remain", remaining)
+	}
+
+	_, filter, err = sourceFilter(url.Values{"gameLine": {"fad"}})
+	expected = bson.M{"$or": []bson.M{
+		{"gameLine": bson.M{"$in": []model.GameLine{model.ForceAndDestiny}}},
+		{"gameLine": bson.M{"$exists": false}, "sources.book": bson.M{"$in": []string{"FaD-CRB", "FaD-KtP"}}},
+	}}
+	if err != nil || !reflect.DeepEqual(filter, expected) {
+		t.Errorf("sourceFilter() error:\ngot: %v, %v\nexpected: %v", filter, err, expected)
+	}
+}
+
+func Test_sourceFilter_Invalid(t *testing.T) {
+	_, _, err := sourceFilter(url.Values{"source": {"Necronomicon"}, "gameLine": {"D&D"}})
+
+	validationErr, ok := err.(model.ValidationError)
+	if !ok || len(validationErr) != 2 {
+		t.Errorf("sourceFilter() error:\ngot: %v\nexpected: source and gameLine validation errors", err)
+	}
+}
+
+func Test_weaponPipeline_Sort(t *testing.T) {
+	_, _, _, sort, err := weaponPipeline(url.Values{"sort": {"-damage,name"}})
+	expected := api.Sort{{Field: effectiveDamage, Descending: true}, {Field: "name"}, {Field: "_id"}}
+	if err != nil || !reflect.DeepEqual(sort, expected) {
+		t.Errorf("weaponPipeline() error:\ngot: %v, %v\nexpected: %v", sort, err, expected)
+	}
+
+	if _, _, _, _, err := weaponPipeline(url.Values{"sort": {"special"}}); err == nil {
+		t.Errorf("weaponPipeline() error:\ngot: <nil>\nexpected: validation error")
+	}
+}
+
+func Test_armorQuery_DefaultSort(t *testing.T) {
+	_, _, sort, _, err := armorQuery(url.Values{})
+	expected := api.Sort{{Field: "_id"}}
+	if err != nil || !reflect.DeepEqual(sort, expected) {
+		t.Errorf("armorQuery() error:\ngot: %v, %v\nexpected: %v", sort, err, expected)
+	}
+}
+
+func Test_sortFields_Stored(t *testing.T) {
+	collections := map[string]struct {
+		sortable map[string]string
+		fields   api.Fields
+	}{
+		"gear":       {gearSortFields, gearFields},
+		"attachment": {attachmentSortFields, attachmentFields},
+		"vehicle":    {vehicleSortFields, vehicleFields},
+		"template":   {templateSortFields, templateFields},
+		"lootTable":  {lootTableSortFields, lootTableFields},
+		"instance":   {instanceSortFields, instanceFields},
+	}
+
+	for name, collection := range collections {
+		for param, field := range collection.sortable {
+			if _, ok := collection.fields[field]; !ok {
+				t.Errorf("%v sort fields error:\ngot: %v sorts on %v\nexpected a stored field", name, param, field)
+			}
+		}
+	}
+
+	if _, err := api.ParseSort("-price,name", gearSortFields); err != nil {
+		t.Errorf("ParseSort() error:\ngot: %v\nexpected: <nil>", err)
+	}
+	if _, err := api.ParseSort("description", gearSortFields); err == nil {
+		t.Errorf("ParseSort() error:\ngot: <nil>\nexpected: description cannot be sorted on")
+	}
+}
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	searchIndex = "search"
+	textScore   = "score"
+)
+
+// textIndexWeights are the fields covered by the text indexes on the armor and weapon collections, matches on the names
+// rank above matches in the skill or the qualities
+var textIndexWeights = bson.D{
+	{Key: "name", Value: 3},
+	{Key: "type", Value: 3},
+	{Key: "skill", Value: 1},
+	{Key: "special", Value: 1},
+}
+
+//CreateTextIndexes creates the text indexes used by Search on the armor and weapon collections, existing indexes are kept
+func (g *GearDB) CreateTextIndexes(ctx context.Context) error {
+	logrus.Debug("BEGIN - CreateTextIndexes")
+
+	keys := bson.D{}
+	weights := bson.M{}
+	for _, field := range textIndexWeights {
+		keys = append(keys, bson.E{Key: field.Key, Value: "text"})
+		weights[field.Key] = field.Value
+	}
+	index := mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName(searchIndex).SetWeights(weights),
+	}
+
+	for _, name := range []string{g.armorCollection, g.weaponCollection} {
+		_, err := g.client.Database(g.databaseName).Collection(name).Indexes().CreateOne(ctx, index)
+		if err != nil {
+			return fmt.Errorf("text index on %v: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+//Search is the database implementation of the full-text search across armor and weapons, the results of every kind
+//searched are merged by relevance and the total counts every match
+func (g *GearDB) Search(search model.SearchQuery, pageNumber, pageCount int) ([]model.SearchResult, int64, error) {
+	logrus.Debugf("BEGIN - Search: %v", search.Text)
+
+	filter := bson.M{"$text": bson.M{"$search": search.Text}}
+	score := bson.M{"$meta": "textScore"}
+
+	// each collection only needs its best matches up to the end of the page, the page is cut from the merged results
+	opts := options.Find().
+		SetMaxTime(30 * time.Second).
+		SetProjection(bson.M{textScore: score}).
+		SetSort(bson.D{{Key: textScore, Value: score}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(pageNumber * pageCount))
+
+	results := []model.SearchResult{}
+	var total int64
+
+	if search.Includes(model.WeaponKind) {
+		collection := g.client.Database(g.databaseName).Collection(g.weaponCollection)
+		count, matches, err := searchCollection(collection, filter, opts, func(cur *mongo.Cursor) (model.SearchResult, error) {
+			elem := struct {
+				model.Weapon `bson:",inline"`
+				Score        float64 `bson:"score"`
+			}{}
+			err := cur.Decode(&elem)
+			if err != nil {
+				return model.SearchResult{}, err
+			}
+			if err := elem.Weapon.NormalizeQualities(); err != nil {
+				logrus.Debugf("Weapon %v has unrecognized qualities: %v", elem.ID.Hex(), err)
+			}
+			weapon := elem.Weapon
+			return model.SearchResult{Kind: model.WeaponKind, ItemID: weapon.ID, Name: weapon.Name, Score: elem.Score, Weapon: &weapon}, nil
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		total += count
+		results = append(results, matches...)
+	}
+
+	if search.Includes(model.ArmorKind) {
+		collection := g.client.Database(g.databaseName).Collection(g.armorCollection)
+		count, matches, err := searchCollection(collection, filter, opts, func(cur *mongo.Cursor) (model.SearchResult, error) {
+			elem := struct {
+				model.Armor `bson:",inline"`
+				Score       float64 `bson:"score"`
+			}{}
+			err := cur.Decode(&elem)
+			if err != nil {
+				return model.SearchResult{}, err
+			}
+			if err := elem.Armor.NormalizeQualities(); err != nil {
+				logrus.Debugf("Armor %v has unrecognized qualities: %v", elem.ID.Hex(), err)
+			}
+			armor := elem.Armor
+			return model.SearchResult{Kind: model.ArmorKind, ItemID: armor.ID, Name: armor.ArmorType, Score: elem.Score, Armor: &armor}, nil
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		total += count
+		results = append(results, matches...)
+	}
+
+	return pageResults(results, pageNumber, pageCount), total, nil
+}
+
+// searchCollection counts the matches in the collection and reads its best matches, decode turns a document into its result
+func searchCollection(collection *mongo.Collection, filter bson.M, opts *options.FindOptions, decode func(cur *mongo.Cursor) (model.SearchResult, error)) (int64, []model.SearchResult, error) {
+	count, err := collection.CountDocuments(context.Background(), filter, options.Count().SetMaxTime(30*time.Second))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	cur, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer cur.Close(context.Background())
+
+	matches := []model.SearchResult{}
+	for cur.Next(context.Background()) {
+		result, err := decode(cur)
+		if err != nil {
+			return 0, nil, err
+		}
+		matches = append(matches, result)
+	}
+
+	return count, matches, cur.Err()
+}
+
+// pageResults orders the merged results by relevance and returns the requested page, equal scores are ordered by kind and
+// id so a result never moves between pages
+func pageResults(results []model.SearchResult, pageNumber, pageCount int) []model.SearchResult {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Kind != results[j].Kind {
+			return results[i].Kind < results[j].Kind
+		}
+		return results[i].ItemID.Hex() < results[j].ItemID.Hex()
+	})
+
+	start := (pageNumber - 1) * pageCount
+	if start < 0 || start >= len(results) {
+		return []model.SearchResult{}
+	}
+	end := start + pageCount
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[start:end]
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_pageResults(t *testing.T) {
+	vibroAxe := model.SearchResult{Kind: model.WeaponKind, ItemID: primitive.NewObjectID(), Name: "Vibro-ax", Score: 3}
+	vibroKnife := model.SearchResult{Kind: model.WeaponKind, ItemID: primitive.NewObjectID(), Name: "Vibroknife", Score: 1.5}
+	armor := model.SearchResult{Kind: model.ArmorKind, ItemID: primitive.NewObjectID(), Name: "Vibro Armor", Score: 1.5}
+	results := []model.SearchResult{vibroKnife, vibroAxe, armor}
+
+	first := pageResults(results, 1, 2)
+	if len(first) != 2 || first[0].ItemID != vibroAxe.ItemID || first[1].ItemID != armor.ItemID {
+		t.Errorf("pageResults() error:\ngot: %v\nexpected: the axe then the armor", first)
+	}
+
+	second := pageResults(results, 2, 2)
+	if len(second) != 1 || second[0].ItemID != vibroKnife.ItemID {
+		t.Errorf("pageResults() error:\ngot: %v\nexpected: the knife", second)
+	}
+
+	if third := pageResults(results, 3, 2); len(third) != 0 {
+		t.Errorf("pageResults() error:\ngot: %v\nexpected: an empty page", third)
+	}
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//GetShopByID is the database implementation to get a specific generated shop back from the database
+func (g *GearDB) GetShopByID(mongoID primitive.ObjectID) (*model.Shop, error) {
+	logrus.Debugf("BEGIN - GetShopByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.shopCollection)
+	query := api.BuildQuery(&mongoID, nil)
+
+	shop := model.Shop{}
+
+	err := collection.FindOne(context.Background(), query).Decode(&shop)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shop, err
+}
+
+//SaveShop replaces the stock of a generated shop, creating it if it does not exist
+func (g *GearDB) SaveShop(shop model.Shop) error {
+	logrus.Debugf("BEGIN - SaveShop: %v", shop.ID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.shopCollection)
+
+	_, err := collection.ReplaceOne(context.Background(), bson.M{"_id": shop.ID}, shop, options.Replace().SetUpsert(true))
+
+	return err
+}
+
+//DeleteShopByID deletes a specific generated shop from the database
+func (g *GearDB) DeleteShopByID(mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - DeleteShopByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.shopCollection)
+
+	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+
+	return err
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetSourceCounts is the database implementation to count the weapons and armor printed in every source book.
+// Books referenced by items but missing from the registry are listed after the registered books.
+func (g *GearDB) GetSourceCounts() ([]model.SourceCount, error) {
+	logrus.Debug("BEGIN - GetSourceCounts")
+
+	weapons, err := g.countBySource(g.weaponCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	armor, err := g.countBySource(g.armorCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeSourceCounts(weapons, armor), nil
+}
+
+// mergeSourceCounts lists the weapon and armor counts of every registered book followed by the unregistered books ordered by code
+func mergeSourceCounts(weapons, armor map[string]int64) []model.SourceCount {
+	counts := []model.SourceCount{}
+	for _, book := range model.SourceBooks() {
+		counts = append(counts, model.SourceCount{SourceBook: book})
+	}
+
+	unregistered := map[string]bool{}
+	for _, found := range []map[string]int64{weapons, armor} {
+		for code := range found {
+			if _, ok := model.LookupSource(code); !ok {
+				unregistered[code] = true
+			}
+		}
+	}
+	codes := make([]string, 0, len(unregistered))
+	for code := range unregistered {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		counts = append(counts, model.SourceCount{SourceBook: model.SourceBook{Code: code}})
+	}
+
+	for i := range counts {
+		counts[i].Weapons = weapons[counts[i].Code]
+		counts[i].Armor = armor[counts[i].Code]
+		counts[i].Total = counts[i].Weapons + counts[i].Armor
+	}
+
+	return counts
+}
+
+// sourceCountPipeline counts the documents referencing each source book, a book listed twice on the same document
+// (on different pages) counts the document once
+var sourceCountPipeline = []bson.M{
+	{"$project": bson.M{"books": bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$sources.book", bson.A{}}}}}}},
+	{"$unwind": "$books"},
+	{"$group": bson.M{"_id": "$books", "count": bson.M{"$sum": 1}}},
+}
+
+// countBySource counts the documents in a collection referencing each source book
+func (g *GearDB) countBySource(collectionName string) (map[string]int64, error) {
+	collection := g.client.Database(g.databaseName).Collection(collectionName)
+
+	opts := options.Aggregate().SetMaxTime(30 * time.Second)
+
+	cur, err := collection.Aggregate(context.Background(), sourceCountPipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	counts := map[string]int64{}
+	for cur.Next(context.Background()) {
+		elem := struct {
+			Book  string `bson:"_id"`
+			Count int64  `bson:"count"`
+		}{}
+		err := cur.Decode(&elem)
+		if err != nil {
+			return nil, err
+		}
+		counts[elem.Book] = elem.Count
+	}
+
+	return counts, cur.Err()
+}
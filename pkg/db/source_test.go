@@ -0,0 +1,44 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_mergeSourceCounts(t *testing.T) {
+	weapons := map[string]int64{"EotE-CRB": 3, "Zeta-HB": 1, "Homebrew": 2}
+	armor := map[string]int64{"EotE-CRB": 1, "Alpha-HB": 4}
+
+	counts := mergeSourceCounts(weapons, armor)
+
+	registered := len(model.SourceBooks())
+	if len(counts) != registered+3 {
+		t.Fatalf("mergeSourceCounts() error:\ngot: %v counts\nexpected: %v", len(counts), registered+3)
+	}
+
+	unregistered := []string{}
+	for _, count := range counts[registered:] {
+		unregistered = append(unregistered, count.Code)
+	}
+	if expected := []string{"Alpha-HB", "Homebrew", "Zeta-HB"}; !reflect.DeepEqual(unregistered, expected) {
+		t.Errorf("mergeSourceCounts() error:\ngot: %v\nexpected: %v", unregistered, expected)
+	}
+
+	for _, count := range counts {
+		if count.Code == "EotE-CRB" && (count.Weapons != 3 || count.Armor != 1 || count.Total != 4) {
+			t.Errorf("mergeSourceCounts() error:\ngot: %+v\nexpected: 3 weapons and 1 armor", count)
+		}
+	}
+}
+
+func Test_sourceCountPipeline_DedupesBooks(t *testing.T) {
+	// the books are made a set per document before they are unwound, so a document lists each book once
+	project := sourceCountPipeline[0]["$project"].(bson.M)["books"]
+	expected := bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$sources.book", bson.A{}}}}}
+	if !reflect.DeepEqual(project, expected) {
+		t.Errorf("sourceCountPipeline error:\ngot: %v\nexpected: %v", project, expected)
+	}
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertVehicle is the database implementation to insert a vehicle object
+func (g *GearDB) InsertVehicle(vehicle *model.Vehicle) error {
+	logrus.Debug("BEGIN - InsertVehicle")
+
+	collection := g.client.Database(g.databaseName).Collection(g.vehicleCollection)
+
+	_, err := collection.InsertOne(context.Background(), vehicle)
+
+	return err
+}
+
+//GetVehicle is the database implementation to get all vehicle objects
+func (g *GearDB) GetVehicle(queryParams url.Values) ([]model.Vehicle, error) {
+	logrus.Debug("BEGIN - GetVehicle")
+
+	collection := g.client.Database(g.databaseName).Collection(g.vehicleCollection)
+
+	queryParams, legal, err := legalFilter(queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNumber, pageCount, sortParam, filter, err := api.BuildFilter(queryParams, vehicleFields)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := api.ParseSort(sortParam, vehicleSortFields)
+	if err != nil {
+		return nil, err
+	}
+	filter = api.MergeFilters(filter, legal)
+	skip := 0
+	if pageNumber > 0 {
+		skip = (pageNumber - 1) * pageCount
+	}
+
+	opts := options.Find().
+		SetMaxTime(30 * time.Second).
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageCount)).
+		SetSort(sort.Document())
+
+	cur, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.Vehicle{}
+
+	for cur.Next(context.Background()) {
+		elem := model.Vehicle{}
+		err := cur.Decode(&elem)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, elem)
+	}
+
+	return matches, nil
+}
+
+//GetVehicleByID is the database implementation to get a specific vehicle back from the database
+func (g *GearDB) GetVehicleByID(mongoID primitive.ObjectID) (*model.Vehicle, error) {
+	logrus.Debugf("BEGIN - GetVehicleByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.vehicleCollection)
+	query := api.BuildQuery(&mongoID, nil)
+
+	vehicle := model.Vehicle{}
+
+	err := collection.FindOne(context.Background(), query).Decode(&vehicle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vehicle, err
+}
+
+//UpdateVehicleByID updates a specific vehicle in the vehicle database
+func (g *GearDB) UpdateVehicleByID(vehicle model.Vehicle, mongoID primitive.ObjectID) error {
+
+	collection := g.client.Database(g.databaseName).Collection(g.vehicleCollection)
+
+	result, err := collection.UpdateOne(context.Background(), bson.M{"_id": mongoID}, bson.D{{
+		Key:   "$set",
+		Value: vehicle,
+	}})
+	if err != nil {
+		return err
+	}
+
+	matched := strconv.FormatInt(result.MatchedCount, 10)
+	modified := strconv.FormatInt(result.ModifiedCount, 10)
+
+	if result.MatchedCount != 1 {
+		return errors.New("Could not update vehicle. Tried to update " + mongoID.Hex() + " got " + matched + " matches instead of 1")
+	}
+
+	if result.ModifiedCount != 1 {
+		return errors.New("Could not update vehicle. Tried to updated " + mongoID.Hex() + " tried to update " + modified + " number of results instead of 1")
+	}
+
+	return nil
+}
+
+//DeleteVehicleByID deletes a specific vehicle from the database
+func (g *GearDB) DeleteVehicleByID(mongoID primitive.ObjectID) error {
+	logrus.Debugf("BEGIN - DeleteVehicleByID: %v", mongoID)
+
+	collection := g.client.Database(g.databaseName).Collection(g.vehicleCollection)
+
+	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+
+	return err
+}
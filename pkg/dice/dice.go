@@ -0,0 +1,174 @@
+// Package dice rolls the FFG narrative dice.
+// Rolls are driven by a seeded source so the same seed and pool always produce the same result.
+package dice
+
+import (
+	"math/rand"
+)
+
+// Symbol is a symbol printed on a die face
+type Symbol int
+
+// The symbols printed on the narrative dice
+const (
+	Success Symbol = iota
+	Failure
+	Advantage
+	Threat
+	Triumph
+	Despair
+	LightSide
+	DarkSide
+)
+
+// Face is the list of symbols on one face of a die, a blank face has none
+type Face []Symbol
+
+// Die is a narrative die described by its faces
+type Die struct {
+	Name  string
+	Faces []Face
+}
+
+// The narrative dice with the face tables from the core rulebook
+var (
+	Boost = Die{Name: "boost", Faces: []Face{
+		{}, {}, {Success}, {Success, Advantage}, {Advantage, Advantage}, {Advantage},
+	}}
+	Setback = Die{Name: "setback", Faces: []Face{
+		{}, {}, {Failure}, {Failure}, {Threat}, {Threat},
+	}}
+	Ability = Die{Name: "ability", Faces: []Face{
+		{}, {Success}, {Success}, {Success, Success}, {Advantage}, {Advantage}, {Success, Advantage}, {Advantage, Advantage},
+	}}
+	Difficulty = Die{Name: "difficulty", Faces: []Face{
+		{}, {Failure}, {Failure, Failure}, {Threat}, {Threat}, {Threat}, {Threat, Threat}, {Failure, Threat},
+	}}
+	Proficiency = Die{Name: "proficiency", Faces: []Face{
+		{}, {Success}, {Success}, {Success, Success}, {Success, Success}, {Advantage}, {Success, Advantage}, {Success, Advantage}, {Success, Advantage}, {Advantage, Advantage}, {Advantage, Advantage}, {Triumph},
+	}}
+	Challenge = Die{Name: "challenge", Faces: []Face{
+		{}, {Failure}, {Failure}, {Failure, Failure}, {Failure, Failure}, {Threat}, {Threat}, {Failure, Threat}, {Failure, Threat}, {Threat, Threat}, {Threat, Threat}, {Despair},
+	}}
+	Force = Die{Name: "force", Faces: []Face{
+		{DarkSide}, {DarkSide}, {DarkSide}, {DarkSide}, {DarkSide}, {DarkSide}, {DarkSide, DarkSide}, {LightSide}, {LightSide}, {LightSide, LightSide}, {LightSide, LightSide}, {LightSide, LightSide},
+	}}
+)
+
+// Pool is the number of each die rolled for a check
+type Pool struct {
+	Ability     int64 `json:"ability"`
+	Proficiency int64 `json:"proficiency"`
+	Difficulty  int64 `json:"difficulty"`
+	Challenge   int64 `json:"challenge"`
+	Boost       int64 `json:"boost"`
+	Setback     int64 `json:"setback"`
+	Force       int64 `json:"force"`
+}
+
+// SkillPool builds the positive dice for a check, the higher of characteristic and skill ranks sets the
+// number of dice and the lower is upgraded from ability to proficiency dice
+func SkillPool(characteristic, ranks int64) Pool {
+	if characteristic < 0 {
+		characteristic = 0
+	}
+	if ranks < 0 {
+		ranks = 0
+	}
+
+	size, upgrades := characteristic, ranks
+	if ranks > characteristic {
+		size, upgrades = ranks, characteristic
+	}
+	return Pool{Ability: size - upgrades, Proficiency: upgrades}
+}
+
+// Result is the tally of every symbol rolled
+type Result struct {
+	Successes  int64 `json:"successes"`
+	Failures   int64 `json:"failures"`
+	Advantages int64 `json:"advantages"`
+	Threats    int64 `json:"threats"`
+	Triumphs   int64 `json:"triumphs"`
+	Despairs   int64 `json:"despairs"`
+	LightSide  int64 `json:"lightSide"`
+	DarkSide   int64 `json:"darkSide"`
+}
+
+// add tallies the symbols on a face, a triumph also counts as a success and a despair as a failure
+func (r *Result) add(face Face) {
+	for _, symbol := range face {
+		switch symbol {
+		case Success:
+			r.Successes++
+		case Failure:
+			r.Failures++
+		case Advantage:
+			r.Advantages++
+		case Threat:
+			r.Threats++
+		case Triumph:
+			r.Triumphs++
+			r.Successes++
+		case Despair:
+			r.Despairs++
+			r.Failures++
+		case LightSide:
+			r.LightSide++
+		case DarkSide:
+			r.DarkSide++
+		}
+	}
+}
+
+// NetSuccesses returns the successes left after failures cancel them, negative values are net failures
+func (r Result) NetSuccesses() int64 {
+	return r.Successes - r.Failures
+}
+
+// NetAdvantages returns the advantages left after threats cancel them, negative values are net threats
+func (r Result) NetAdvantages() int64 {
+	return r.Advantages - r.Threats
+}
+
+// Succeeded reports whether the check had at least one net success
+func (r Result) Succeeded() bool {
+	return r.NetSuccesses() > 0
+}
+
+// Roller rolls dice pools from a seeded source
+type Roller struct {
+	random *rand.Rand
+}
+
+// NewRoller returns a roller seeded with the given seed
+func NewRoller(seed int64) *Roller {
+	return &Roller{random: rand.New(rand.NewSource(seed))}
+}
+
+// RollDie rolls a single die and returns the face that came up
+func (r *Roller) RollDie(die Die) Face {
+	return die.Faces[r.random.Intn(len(die.Faces))]
+}
+
+// Roll rolls every die in the pool and tallies the symbols
+func (r *Roller) Roll(pool Pool) Result {
+	result := Result{}
+	for _, group := range []struct {
+		die   Die
+		count int64
+	}{
+		{Ability, pool.Ability},
+		{Proficiency, pool.Proficiency},
+		{Difficulty, pool.Difficulty},
+		{Challenge, pool.Challenge},
+		{Boost, pool.Boost},
+		{Setback, pool.Setback},
+		{Force, pool.Force},
+	} {
+		for i := int64(0); i < group.count; i++ {
+			result.add(r.RollDie(group.die))
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package dice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDice_FaceTables(t *testing.T) {
+	tests := []struct {
+		die      Die
+		faces    int
+		expected Result
+	}{
+		{Boost, 6, Result{Successes: 2, Advantages: 4}},
+		{Setback, 6, Result{Failures: 2, Threats: 2}},
+		{Ability, 8, Result{Successes: 5, Advantages: 5}},
+		{Difficulty, 8, Result{Failures: 4, Threats: 6}},
+		{Proficiency, 12, Result{Successes: 10, Advantages: 8, Triumphs: 1}},
+		{Challenge, 12, Result{Failures: 9, Threats: 8, Despairs: 1}},
+		{Force, 12, Result{LightSide: 8, DarkSide: 8}},
+	}
+
+	for _, test := range tests {
+		total := Result{}
+		for _, face := range test.die.Faces {
+			total.add(face)
+		}
+		if len(test.die.Faces) != test.faces || total != test.expected {
+			t.Errorf("%v die error:\ngot: %v faces %+v\nexpected: %v faces %+v", test.die.Name, len(test.die.Faces), total, test.faces, test.expected)
+		}
+	}
+}
+
+func TestSkillPool(t *testing.T) {
+	tests := []struct {
+		characteristic int64
+		ranks          int64
+		expected       Pool
+	}{
+		{3, 0, Pool{Ability: 3}},
+		{3, 2, Pool{Ability: 1, Proficiency: 2}},
+		{2, 4, Pool{Ability: 2, Proficiency: 2}},
+		{2, 2, Pool{Proficiency: 2}},
+	}
+
+	for _, test := range tests {
+		if pool := SkillPool(test.characteristic, test.ranks); pool != test.expected {
+			t.Errorf("SkillPool(%v, %v) error:\ngot: %+v\nexpected: %+v", test.characteristic, test.ranks, pool, test.expected)
+		}
+	}
+}
+
+func TestRoller_Roll_Seeded(t *testing.T) {
+	pool := Pool{Ability: 2, Proficiency: 2, Difficulty: 2, Challenge: 1, Boost: 1, Setback: 1, Force: 1}
+
+	first := NewRoller(42).Roll(pool)
+	second := NewRoller(42).Roll(pool)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Roll() error:\ngot: %+v and %+v\nexpected: the same result for the same seed", first, second)
+	}
+	if first.NetSuccesses() != first.Successes-first.Failures || first.NetAdvantages() != first.Advantages-first.Threats {
+		t.Errorf("Roll() error:\ngot: %+v\nexpected: net results to cancel opposing symbols", first)
+	}
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//InsertAttachment is the handler function for inserting an attachment object
+func (s *GearService) InsertAttachment(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertAttachment invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	var attachmentModel model.Attachment
+	attachmentModel.ID = primitive.NewObjectID()
+
+	err := json.NewDecoder(r.Body).Decode(&attachmentModel)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = attachmentModel.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.InsertAttachment(&attachmentModel)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, "Attachment Object Created")
+}
+
+//GetAttachment is the handler function to return all attachments in the database
+func (s *GearService) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetAttachment invoked with url: %v", r.URL)
+
+	attachments, err := s.Database.GetAttachment(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, attachments)
+}
+
+//GetAttachmentByID is the handler function to return a specific attachment in the database
+func (s *GearService) GetAttachmentByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetAttachmentByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := api.StringToObjectID(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	attachment, err := s.Database.GetAttachmentByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, attachment)
+}
+
+//UpdateAttachmentByID is the handler function to update a specific attachment in the database
+func (s *GearService) UpdateAttachmentByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateAttachmentByID invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	attachment := model.Attachment{}
+	err = json.NewDecoder(r.Body).Decode(&attachment)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = attachment.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.UpdateAttachmentByID(attachment, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, objectID)
+}
+
+//DeleteAttachmentByID is the handler function to remove a specific attachment in the database
+func (s *GearService) DeleteAttachmentByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("DeleteAttachmentByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	// instances keep their modifications per attachment, the attachment has to be removed from them first
+	instances, err := s.Database.CountAttachmentInstances(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+	if instances > 0 {
+		api.RespondWithError(w, http.StatusConflict, fmt.Sprintf("attachment %v is installed on %v item instances, remove it from them first", ID, instances))
+		return
+	}
+
+	// catalog weapons and armor would keep counting the hard points of an attachment that no longer exists
+	items, err := s.Database.CountAttachmentItems(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+	if items > 0 {
+		api.RespondWithError(w, http.StatusConflict, fmt.Sprintf("attachment %v is installed on %v weapons and armor, remove it from them first", ID, items))
+		return
+	}
+
+	err = s.Database.DeleteAttachmentByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+//GetArmorAttachments is the handler function to return the attachments installed on a specific armor
+func (s *GearService) GetArmorAttachments(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetArmorAttachments invoked with url: %v", r.URL)
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	armor, err := s.Database.GetArmorByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, installedOrEmpty(armor.Attachments))
+}
+
+//GetWeaponAttachments is the handler function to return the attachments installed on a specific weapon
+func (s *GearService) GetWeaponAttachments(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetWeaponAttachments invoked with url: %v", r.URL)
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, installedOrEmpty(weapon.Attachments))
+}
+
+//InstallArmorAttachment is the handler function to install an attachment on a specific armor
+func (s *GearService) InstallArmorAttachment(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InstallArmorAttachment invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	armor, err := s.Database.GetArmorByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	attachments, code, err := s.installAttachment(r, model.ArmorKind, armor.HardPoints, armor.Attachments)
+	if err != nil {
+		api.RespondWithError(w, code, err.Error())
+		return
+	}
+
+	err = s.Database.UpdateArmorAttachments(attachments, armor.Attachments, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, attachments)
+}
+
+//InstallWeaponAttachment is the handler function to install an attachment on a specific weapon
+func (s *GearService) InstallWeaponAttachment(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InstallWeaponAttachment invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	attachments, code, err := s.installAttachment(r, model.WeaponKind, weapon.HP, weapon.Attachments)
+	if err != nil {
+		api.RespondWithError(w, code, err.Error())
+		return
+	}
+
+	err = s.Database.UpdateWeaponAttachments(attachments, weapon.Attachments, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, attachments)
+}
+
+//RemoveArmorAttachment is the handler function to remove an installed attachment from a specific armor
+func (s *GearService) RemoveArmorAttachment(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("RemoveArmorAttachment invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	objectID, err := api.StringToObjectID(vars["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	armor, err := s.Database.GetArmorByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	attachments, err := removeAttachment(armor.Attachments, vars["attachmentID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.UpdateArmorAttachments(attachments, armor.Attachments, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+//RemoveWeaponAttachment is the handler function to remove an installed attachment from a specific weapon
+func (s *GearService) RemoveWeaponAttachment(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("RemoveWeaponAttachment invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	objectID, err := api.StringToObjectID(vars["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	attachments, err := removeAttachment(weapon.Attachments, vars["attachmentID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.UpdateWeaponAttachments(attachments, weapon.Attachments, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+// installAttachment reads the install request and returns the item's attachments with the requested attachment added.
+// Installs on the wrong kind of item or beyond the item's free hard points are rejected.
+func (s *GearService) installAttachment(r *http.Request, kind model.ItemKind, hardPoints int64, installed []model.InstalledAttachment) ([]model.InstalledAttachment, int, error) {
+	request := model.InstallAttachmentRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil || request.AttachmentID.IsZero() {
+		return nil, http.StatusBadRequest, fmt.Errorf("Invalid Request Payload, attachmentId is required")
+	}
+
+	attachment, err := s.Database.GetAttachmentByID(request.AttachmentID)
+	if err != nil {
+		return nil, api.CheckError(err), err
+	}
+
+	if !attachment.Allows(kind) {
+		return nil, http.StatusBadRequest, fmt.Errorf("attachment %v cannot be installed on %v", attachment.Name, kind)
+	}
+
+	free := hardPoints - model.UsedHardPoints(installed)
+	if attachment.HardPoints > free {
+		return nil, http.StatusConflict, fmt.Errorf("attachment %v needs %v hard points but only %v are free", attachment.Name, attachment.HardPoints, free)
+	}
+
+	return append(installedOrEmpty(installed), attachment.Installed()), http.StatusOK, nil
+}
+
+// removeAttachment returns the attachments without the first installed copy of the given attachment
+func removeAttachment(installed []model.InstalledAttachment, attachmentID string) ([]model.InstalledAttachment, error) {
+	for i, attachment := range installed {
+		if attachment.AttachmentID.Hex() == attachmentID {
+			remaining := append([]model.InstalledAttachment{}, installed[:i]...)
+			return append(remaining, installed[i+1:]...), nil
+		}
+	}
+	return nil, fmt.Errorf("attachment %v not found on item", attachmentID)
+}
+
+func installedOrEmpty(installed []model.InstalledAttachment) []model.InstalledAttachment {
+	if installed == nil {
+		return []model.InstalledAttachment{}
+	}
+	return installed
+}
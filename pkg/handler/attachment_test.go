@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mockAttachment(id primitive.ObjectID, name string, hardPoints int64, kinds ...model.ItemKind) model.Attachment {
+	return model.Attachment{
+		ID:           id,
+		Name:         name,
+		HardPoints:   hardPoints,
+		AllowedKinds: kinds,
+	}
+}
+
+func installRequest(attachmentID primitive.ObjectID) *bytes.Buffer {
+	request, _ := json.Marshal(model.InstallAttachmentRequest{AttachmentID: attachmentID})
+	return bytes.NewBuffer(request)
+}
+
+func TestGearService_InsertAttachment_Success(t *testing.T) {
+	attachment := mockAttachment(primitive.NewObjectID(), "Custom Grip", 1, model.WeaponKind)
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	request, _ := json.Marshal(attachment)
+
+	r, err := http.NewRequest("POST", "/attachment", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("InsertAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("InsertAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestGearService_InsertAttachment_Invalid(t *testing.T) {
+	attachment := mockAttachment(primitive.NewObjectID(), "", -1, "starship")
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	request, _ := json.Marshal(attachment)
+
+	r, err := http.NewRequest("POST", "/attachment", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("InsertAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InsertAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || len(resp.Fields) != 3 {
+		t.Errorf("InsertAttachment() error:\ngot: %v\nexpected: 3 field errors", resp)
+	}
+}
+
+func TestGearService_GetAttachmentByID_DBError(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, errors.New("no documents in result"))
+
+	r, err := http.NewRequest("GET", "/attachment/"+primitive.NewObjectID().Hex(), nil)
+	if err != nil {
+		t.Errorf("GetAttachmentByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GetAttachmentByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestGearService_DeleteAttachmentByID_InstalledOnInstances(t *testing.T) {
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{AttachmentInstances: 2}}
+
+	r, err := http.NewRequest("DELETE", "/attachment/"+primitive.NewObjectID().Hex(), nil)
+	if err != nil {
+		t.Errorf("DeleteAttachmentByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("DeleteAttachmentByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusConflict)
+	}
+}
+
+func TestGearService_DeleteAttachmentByID_InstalledOnCatalogItems(t *testing.T) {
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{AttachmentItems: 1}}
+
+	r, err := http.NewRequest("DELETE", "/attachment/"+primitive.NewObjectID().Hex(), nil)
+	if err != nil {
+		t.Errorf("DeleteAttachmentByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "weapons and armor") {
+		t.Errorf("DeleteAttachmentByID() error:\ngot: %v %v\nexpected: %v", w.Code, w.Body.String(), http.StatusConflict)
+	}
+}
+
+func TestGearService_InstallWeaponAttachment_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	weapon.HP = 3
+	weapon.Attachments = []model.InstalledAttachment{{AttachmentID: primitive.NewObjectID(), Name: "Multi-optic Sight", HardPoints: 1}}
+	attachment := mockAttachment(primitive.NewObjectID(), "Custom Grip", 1, model.WeaponKind)
+	db := &mocks.MockGearDatabase{WeaponToReturn: &weapon, AttachmentToReturn: &attachment}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/weapon/"+weapon.ID.Hex()+"/attachments", installRequest(attachment.ID))
+	if err != nil {
+		t.Errorf("InstallWeaponAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("InstallWeaponAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+	if len(db.InstalledAttachments) != 2 || db.InstalledAttachments[1].AttachmentID != attachment.ID {
+		t.Errorf("InstallWeaponAttachment() error:\ngot: %v\nexpected: Custom Grip installed", db.InstalledAttachments)
+	}
+}
+
+func TestGearService_InstallWeaponAttachment_NoHardPoints(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Holdout Blaster", 200)
+	weapon.HP = 1
+	attachment := mockAttachment(primitive.NewObjectID(), "Underbarrel Grenade Launcher", 2, model.WeaponKind)
+	db := &mocks.MockGearDatabase{WeaponToReturn: &weapon, AttachmentToReturn: &attachment}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/weapon/"+weapon.ID.Hex()+"/attachments", installRequest(attachment.ID))
+	if err != nil {
+		t.Errorf("InstallWeaponAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("InstallWeaponAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusConflict)
+	}
+	if db.InstalledAttachments != nil {
+		t.Errorf("InstallWeaponAttachment() error:\ngot: %v\nexpected: no update", db.InstalledAttachments)
+	}
+}
+
+func TestGearService_InstallWeaponAttachment_ConcurrentChange(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	weapon.HP = 2
+	attachment := mockAttachment(primitive.NewObjectID(), "Custom Grip", 1, model.WeaponKind)
+	db := &mocks.MockGearDatabase{WeaponToReturn: &weapon, AttachmentToReturn: &attachment, AttachmentsChanged: true}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/weapon/"+weapon.ID.Hex()+"/attachments", installRequest(attachment.ID))
+	if err != nil {
+		t.Errorf("InstallWeaponAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("InstallWeaponAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusConflict)
+	}
+}
+
+func TestGearService_InstallArmorAttachment_WrongKind(t *testing.T) {
+	armor := mockSingleArmor(primitive.NewObjectID(), "Padded Armor", 500)
+	armor.HardPoints = 2
+	attachment := mockAttachment(primitive.NewObjectID(), "Custom Grip", 1, model.WeaponKind)
+	db := &mocks.MockGearDatabase{ArmorToReturn: &armor, AttachmentToReturn: &attachment}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/armor/"+armor.ID.Hex()+"/attachments", installRequest(attachment.ID))
+	if err != nil {
+		t.Errorf("InstallArmorAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InstallArmorAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_RemoveArmorAttachment(t *testing.T) {
+	attachmentID := primitive.NewObjectID()
+	armor := mockSingleArmor(primitive.NewObjectID(), "Armored Clothing", 1000)
+	armor.HardPoints = 1
+	armor.Attachments = []model.InstalledAttachment{{AttachmentID: attachmentID, Name: "Deflective Plating", HardPoints: 1}}
+	db := &mocks.MockGearDatabase{ArmorToReturn: &armor}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("DELETE", "/armor/"+armor.ID.Hex()+"/attachments/"+attachmentID.Hex(), nil)
+	if err != nil {
+		t.Errorf("RemoveArmorAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("RemoveArmorAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNoContent)
+	}
+	if db.InstalledAttachments == nil || len(db.InstalledAttachments) != 0 {
+		t.Errorf("RemoveArmorAttachment() error:\ngot: %v\nexpected: no attachments", db.InstalledAttachments)
+	}
+}
+
+func TestGearService_RemoveWeaponAttachment_NotInstalled(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	service := InitMockGearService(nil, nil, &weapon, nil, nil)
+
+	r, err := http.NewRequest("DELETE", "/weapon/"+weapon.ID.Hex()+"/attachments/"+primitive.NewObjectID().Hex(), nil)
+	if err != nil {
+		t.Errorf("RemoveWeaponAttachment() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("RemoveWeaponAttachment() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNotFound)
+	}
+}
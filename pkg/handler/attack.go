@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/geeksheik9/gear-CRUD/pkg/combat"
+	"github.com/geeksheik9/gear-CRUD/pkg/dice"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+//RollWeaponAttack is the handler function to roll an attack with a weapon, the seed is returned so the roll can be repeated
+func (s *GearService) RollWeaponAttack(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("RollWeaponAttack invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	request := combat.AttackRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = request.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	seed := time.Now().UnixNano()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+
+	result, err := combat.Attack(weapon, request, dice.NewRoller(seed))
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	result.Seed = seed
+
+	api.RespondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/combat"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func rollAttack(t *testing.T, service GearService, weaponID primitive.ObjectID, body string) (*httptest.ResponseRecorder, combat.AttackResult) {
+	r, err := http.NewRequest("POST", "/weapon/"+weaponID.Hex()+"/attack", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("RollWeaponAttack() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := combat.AttackResult{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return w, resp
+}
+
+func TestGearService_RollWeaponAttack_Seeded(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	weapon.Skill = "Ranged (Light)"
+	weapon.Damage = model.Damage{Base: 6}
+	weapon.Critical = 3
+	service := InitMockGearService(nil, nil, &weapon, nil, nil)
+
+	body := `{"characteristics": {"agility": 3}, "skills": {"Ranged (Light)": 2}, "difficulty": 2, "seed": 7}`
+	w, first := rollAttack(t, service, weapon.ID, body)
+	_, second := rollAttack(t, service, weapon.ID, body)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("RollWeaponAttack() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+	if first != second || first.Seed != 7 || first.Characteristic != combat.Agility || first.Pool.Proficiency != 2 || first.Pool.Ability != 1 {
+		t.Errorf("RollWeaponAttack() error:\ngot: %+v and %+v\nexpected: matching seeded rolls", first, second)
+	}
+}
+
+func TestGearService_RollWeaponAttack_NotCombatSkill(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Hydrospanner", 25)
+	weapon.Skill = "Mechanics"
+	service := InitMockGearService(nil, nil, &weapon, nil, nil)
+
+	w, _ := rollAttack(t, service, weapon.ID, `{"characteristics": {"intellect": 3}}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("RollWeaponAttack() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_RollWeaponAttack_Invalid(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	w, _ := rollAttack(t, service, primitive.NewObjectID(), `{"characteristics": {"agility": 0}, "boost": -1}`)
+
+	resp := model.ValidationErrorResponse{}
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 2 {
+		t.Errorf("RollWeaponAttack() error:\ngot: %v %+v\nexpected: %v with characteristic and boost errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	locationParam    = "location"
+	blackMarketParam = "blackMarket"
+)
+
+//GetArmorAvailability is the handler function to return the purchase difficulty of an armor
+func (s *GearService) GetArmorAvailability(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetArmorAvailability invoked with url: %v", r.URL)
+
+	request, err := availabilityRequest(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	armor, err := s.Database.GetArmorByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	availability := request.Check(armor.Rarity, armor.Restricted)
+	availability.ItemID = armor.ID.Hex()
+	availability.Name = armor.ArmorType
+
+	api.RespondWithJSON(w, http.StatusOK, availability)
+}
+
+//GetWeaponAvailability is the handler function to return the purchase difficulty of a weapon
+func (s *GearService) GetWeaponAvailability(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetWeaponAvailability invoked with url: %v", r.URL)
+
+	request, err := availabilityRequest(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	availability := request.Check(weapon.Rarity, weapon.Restricted)
+	availability.ItemID = weapon.ID.Hex()
+	availability.Name = weapon.Name
+
+	api.RespondWithJSON(w, http.StatusOK, availability)
+}
+
+// availabilityRequest reads the location modifier and black market flag from the query
+func availabilityRequest(query url.Values) (model.AvailabilityRequest, error) {
+	request := model.AvailabilityRequest{}
+	validationErr := model.ValidationError{}
+
+	location, modifier, err := model.ParseLocationModifier(query.Get(locationParam))
+	if err != nil {
+		validationErr.Add(locationParam, err.Error())
+	}
+	request.Location, request.LocationModifier = location, modifier
+
+	if value := query.Get(blackMarketParam); value != "" {
+		request.BlackMarket, err = strconv.ParseBool(value)
+		if err != nil {
+			validationErr.Add(blackMarketParam, fmt.Sprintf("%v is not true or false", value))
+		}
+	}
+
+	return request, validationErr.OrNil()
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGearService_GetWeaponAvailability_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Heavy Blaster Pistol", 700)
+	weapon.Rarity = 6
+	weapon.Restricted = true
+	service := InitMockGearService(nil, nil, &weapon, nil, nil)
+
+	r, err := http.NewRequest("GET", "/weapon/"+weapon.ID.Hex()+"/availability?location=Outer%20Rim&blackMarket=true", nil)
+	if err != nil {
+		t.Errorf("GetWeaponAvailability() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.Availability{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil {
+		t.Errorf("GetWeaponAvailability() error:\ngot: %v %v\nexpected: %v", w.Code, err, http.StatusOK)
+	}
+	if resp.Name != "Heavy Blaster Pistol" || resp.AdjustedRarity != 6 || resp.Difficulty != "Hard" || resp.DifficultyDice != 3 {
+		t.Errorf("GetWeaponAvailability() error:\ngot: %+v\nexpected: rarity 6, Hard", resp)
+	}
+}
+
+func TestGearService_GetArmorAvailability_Success(t *testing.T) {
+	armor := mockSingleArmor(primitive.NewObjectID(), "Padded Armor", 500)
+	armor.Rarity = 1
+	service := InitMockGearService(&armor, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("GET", "/armor/"+armor.ID.Hex()+"/availability?location=3", nil)
+	if err != nil {
+		t.Errorf("GetArmorAvailability() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.Availability{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.AdjustedRarity != 4 || resp.Difficulty != "Average" {
+		t.Errorf("GetArmorAvailability() error:\ngot: %v %+v\nexpected: %v with rarity 4, Average", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_GetWeaponAvailability_InvalidQuery(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("GET", "/weapon/"+primitive.NewObjectID().Hex()+"/availability?location=Kessel&blackMarket=maybe", nil)
+	if err != nil {
+		t.Errorf("GetWeaponAvailability() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 2 {
+		t.Errorf("GetWeaponAvailability() error:\ngot: %v %+v\nexpected: %v with location and blackMarket errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetArmorAvailability_NotFound(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, mongo.ErrNoDocuments)
+
+	r, err := http.NewRequest("GET", "/armor/"+primitive.NewObjectID().Hex()+"/availability", nil)
+	if err != nil {
+		t.Errorf("GetArmorAvailability() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GetArmorAvailability() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNotFound)
+	}
+}
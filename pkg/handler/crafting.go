@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//InsertTemplate is the handler function for inserting a crafting template
+func (s *GearService) InsertTemplate(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertTemplate invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	var templateModel model.CraftingTemplate
+	templateModel.ID = primitive.NewObjectID()
+
+	err := json.NewDecoder(r.Body).Decode(&templateModel)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = templateModel.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.InsertTemplate(&templateModel)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, "Template Object Created")
+}
+
+//GetTemplate is the handler function to return all crafting templates in the database
+func (s *GearService) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetTemplate invoked with url: %v", r.URL)
+
+	templates, err := s.Database.GetTemplate(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, templates)
+}
+
+//GetTemplateByID is the handler function to return a specific crafting template in the database
+func (s *GearService) GetTemplateByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetTemplateByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := api.StringToObjectID(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	template, err := s.Database.GetTemplateByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, template)
+}
+
+//UpdateTemplateByID is the handler function to update a specific crafting template in the database
+func (s *GearService) UpdateTemplateByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateTemplateByID invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	template := model.CraftingTemplate{}
+	err = json.NewDecoder(r.Body).Decode(&template)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = template.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.UpdateTemplateByID(template, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, objectID)
+}
+
+//DeleteTemplateByID is the handler function to remove a specific crafting template in the database
+func (s *GearService) DeleteTemplateByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("DeleteTemplateByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.DeleteTemplateByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+//Craft is the handler function to craft a new weapon or armor from a template with the outcome of the crafting check
+func (s *GearService) Craft(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("Craft invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	request := model.CraftRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = request.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	template, err := s.Database.GetTemplateByID(request.TemplateID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	result, err := template.Craft(request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	switch {
+	case result.Weapon != nil:
+		err = result.Weapon.Validate()
+		if err == nil {
+			err = s.Database.InsertWeapon(result.Weapon)
+		}
+	case result.Armor != nil:
+		err = result.Armor.Validate()
+		if err == nil {
+			err = s.Database.InsertArmor(result.Armor)
+		}
+	}
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mockTemplate() model.CraftingTemplate {
+	return model.CraftingTemplate{
+		ID:            primitive.NewObjectID(),
+		Name:          "Reinforced Padding",
+		Kind:          model.ArmorKind,
+		MaterialPrice: 300,
+		Difficulty:    2,
+		TimeHours:     4,
+		Armor:         &model.Armor{Soak: 1, Encumbrance: 2, HardPoints: 1, Price: 500},
+	}
+}
+
+func TestGearService_InsertTemplate_Invalid(t *testing.T) {
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{}}
+
+	r, err := http.NewRequest("POST", "/template", bytes.NewBufferString(`{"name": "Frame", "kind": "gear", "difficulty": 2}`))
+	if err != nil {
+		t.Errorf("InsertTemplate() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InsertTemplate() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetTemplateByID_Success(t *testing.T) {
+	template := mockTemplate()
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{TemplateToReturn: &template}}
+
+	r, err := http.NewRequest("GET", "/template/"+template.ID.Hex(), nil)
+	if err != nil {
+		t.Errorf("GetTemplateByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.CraftingTemplate{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.Name != template.Name || resp.Armor == nil {
+		t.Errorf("GetTemplateByID() error:\ngot: %v %+v\nexpected: %v %+v", w.Code, resp, http.StatusOK, template)
+	}
+}
+
+func TestGearService_Craft_Success(t *testing.T) {
+	template := mockTemplate()
+	db := &mocks.MockGearDatabase{TemplateToReturn: &template}
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"templateId": "` + template.ID.Hex() + `", "name": "Custom Padding", "successes": 1, "advantages": 5, "modifications": ["soak", "encumbrance"]}`
+	r, err := http.NewRequest("POST", "/craft", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("Craft() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.CraftResult{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || !resp.Crafted {
+		t.Fatalf("Craft() error:\ngot: %v %+v %v\nexpected: %v with a crafted armor", w.Code, resp, err, http.StatusOK)
+	}
+
+	inserted := db.InsertedArmor
+	if inserted == nil || inserted.ArmorType != "Custom Padding" || inserted.Soak != 2 || inserted.Encumbrance != 1 || inserted.ID.Hex() != resp.ItemID {
+		t.Errorf("Craft() error:\ngot: %+v\nexpected the modified armor to be inserted", inserted)
+	}
+}
+
+func TestGearService_Craft_Failed(t *testing.T) {
+	template := mockTemplate()
+	db := &mocks.MockGearDatabase{TemplateToReturn: &template}
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"templateId": "` + template.ID.Hex() + `", "successes": -1, "advantages": 1}`
+	r, err := http.NewRequest("POST", "/craft", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("Craft() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.CraftResult{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.Crafted || db.InsertedArmor != nil {
+		t.Errorf("Craft() error:\ngot: %v %+v\nexpected: %v without an inserted item", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_Craft_TooManyModifications(t *testing.T) {
+	template := mockTemplate()
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{TemplateToReturn: &template}}
+
+	body := `{"templateId": "` + template.ID.Hex() + `", "successes": 3, "advantages": 2, "modifications": ["defense"]}`
+	r, err := http.NewRequest("POST", "/craft", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("Craft() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Craft() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
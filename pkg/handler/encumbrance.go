@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+)
+
+//CalculateEncumbrance is the handler function to total the encumbrance of a set of items for a character
+func (s *GearService) CalculateEncumbrance(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("CalculateEncumbrance invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	request := model.EncumbranceRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = request.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.validateInventoryItems(request.Items)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, request.Calculate())
+}
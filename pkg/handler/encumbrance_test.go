@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGearService_CalculateEncumbrance_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Rifle", 900)
+	weapon.Encumberence = 4
+	armor := mockSingleArmor(primitive.NewObjectID(), "Laminate", 2500)
+	armor.Encumbrance = 4
+	db := &mocks.MockGearDatabase{
+		WeaponByID: map[primitive.ObjectID]*model.Weapon{weapon.ID: &weapon},
+		ArmorByID:  map[primitive.ObjectID]*model.Armor{armor.ID: &armor},
+	}
+	service := GearService{Version: "test", Database: db}
+
+	request, _ := json.Marshal(model.EncumbranceRequest{
+		Brawn: 1,
+		Items: []model.InventoryItem{
+			{Kind: model.WeaponKind, ItemID: weapon.ID, Quantity: 2},
+			{Kind: model.ArmorKind, ItemID: armor.ID, Quantity: 1, State: model.Equipped},
+		},
+	})
+
+	r, err := http.NewRequest("POST", "/encumbrance", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("CalculateEncumbrance() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.EncumbranceReport{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil {
+		t.Errorf("CalculateEncumbrance() error:\ngot: %v %v\nexpected: %v", w.Code, err, http.StatusOK)
+	}
+	if resp.Threshold != 6 || resp.Load != 9 || resp.Setback != 3 || !resp.OverEncumbered || !resp.FreeManeuverLost {
+		t.Errorf("CalculateEncumbrance() error:\ngot: %+v\nexpected: load 9 over a threshold of 6", resp)
+	}
+}
+
+func TestGearService_CalculateEncumbrance_MissingItem(t *testing.T) {
+	db := &mocks.MockGearDatabase{ArmorByID: map[primitive.ObjectID]*model.Armor{}}
+	service := GearService{Version: "test", Database: db}
+
+	request, _ := json.Marshal(model.EncumbranceRequest{
+		Brawn: 2,
+		Items: []model.InventoryItem{{Kind: model.ArmorKind, ItemID: primitive.NewObjectID(), Quantity: 1}},
+	})
+
+	r, err := http.NewRequest("POST", "/encumbrance", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("CalculateEncumbrance() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "items[0].itemId" {
+		t.Errorf("CalculateEncumbrance() error:\ngot: %v %+v\nexpected: %v with an items[0].itemId error", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_CalculateEncumbrance_InvalidPayload(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("POST", "/encumbrance", bytes.NewBufferString(`{"brawn": "strong"}`))
+	if err != nil {
+		t.Errorf("CalculateEncumbrance() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("CalculateEncumbrance() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//InsertGear is the handler function for inserting a gear object
+func (s *GearService) InsertGear(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertGear invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	var gearModel model.Gear
+	gearModel.ID = primitive.NewObjectID()
+
+	err := json.NewDecoder(r.Body).Decode(&gearModel)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = gearModel.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.InsertGear(&gearModel)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, "Gear Object Created")
+}
+
+//GetGear is the handler function to return all gear in the database, one page at a time
+func (s *GearService) GetGear(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetGear invoked with url: %v", r.URL)
+
+	query, pageNumber, pageCount, err := api.ParsePage(r.URL.Query(), s.MaxPageSize)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	gear, err := s.Database.GetGear(query)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	if gear == nil {
+		gear = []model.Gear{}
+	}
+
+	total, err := s.Database.CountGear(query)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithPage(w, r.URL, model.NewPage(gear, total, pageNumber, pageCount))
+}
+
+//GetGearByID is the handler function to return a specific gear in the database
+func (s *GearService) GetGearByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetGearByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := api.StringToObjectID(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	gear, err := s.Database.GetGearByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, gear)
+}
+
+//UpdateGearByID is the handler function to update a specific gear in the database
+func (s *GearService) UpdateGearByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateGearByID invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	gear := model.Gear{}
+	err = json.NewDecoder(r.Body).Decode(&gear)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = gear.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.UpdateGearByID(gear, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, objectID)
+}
+
+//DeleteGearByID is the handler function to remove a specific gear in the database
+func (s *GearService) DeleteGearByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("DeleteGearByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.DeleteGearByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mockGear(id primitive.ObjectID, name string, price int64) model.Gear {
+	return model.Gear{
+		ID:       id,
+		Name:     name,
+		Category: "Medical",
+		Price:    price,
+	}
+}
+
+func TestGearService_InsertGear_Success(t *testing.T) {
+	gear := mockGear(primitive.NewObjectID(), "Stimpack", 25)
+	gear.Consumable = true
+	gear.Uses = 1
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	request, _ := json.Marshal(gear)
+
+	r, err := http.NewRequest("POST", "/gear", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("InsertGear() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("InsertGear() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestGearService_InsertGear_Invalid(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("POST", "/gear", bytes.NewBufferString(`{"name": "Stimpack", "consumable": true, "rarity": "12 (R)"}`))
+	if err != nil {
+		t.Errorf("InsertGear() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InsertGear() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || len(resp.Fields) != 2 {
+		t.Errorf("InsertGear() error:\ngot: %v\nexpected: rarity and uses field errors", resp)
+	}
+}
+
+func TestGearService_GetGear_Success(t *testing.T) {
+	gear := mockGear(primitive.NewObjectID(), "Comlink (handheld)", 25)
+	db := &mocks.MockGearDatabase{GearsToReturn: []model.Gear{gear}}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/gear?category=Medical", nil)
+	if err != nil {
+		t.Errorf("GetGear() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("GetGear() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+
+	page := struct {
+		Items []model.Gear `json:"items"`
+		Total int64        `json:"total"`
+	}{}
+	err = json.NewDecoder(w.Body).Decode(&page)
+	resp := page.Items
+	if err != nil || page.Total != 1 || len(resp) != 1 || resp[0].ID != gear.ID || resp[0].Name != gear.Name {
+		t.Errorf("GetGear() error:\ngot: %v\nexpected: %v", resp, gear)
+	}
+}
+
+func TestGearService_GetGear_Paged(t *testing.T) {
+	db := &mocks.MockGearDatabase{GearsToReturn: []model.Gear{mockGear(primitive.NewObjectID(), "Stimpack", 25)}, CountToReturn: 12}
+	service := GearService{Version: "test", Database: db, MaxPageSize: 5}
+
+	r, err := http.NewRequest("GET", "/gear?pageNumber=3", nil)
+	if err != nil {
+		t.Errorf("GetGear() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	page := model.Page{}
+	err = json.NewDecoder(w.Body).Decode(&page)
+	if w.Code != http.StatusOK || err != nil || page.Total != 12 || page.PageNumber != 3 || page.PageSize != 5 || page.TotalPages != 3 || page.HasNext {
+		t.Errorf("GetGear() error:\ngot: %v %+v\nexpected: the last page of 5 with 12 total", w.Code, page)
+	}
+}
+
+func TestGearService_GetGearByID_Success(t *testing.T) {
+	gear := mockGear(primitive.NewObjectID(), "Medpac", 400)
+	db := &mocks.MockGearDatabase{GearToReturn: &gear}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/gear/"+gear.ID.Hex(), nil)
+	if err != nil {
+		t.Errorf("GetGearByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("GetGearByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestGearService_UpdateGearByID_DBError(t *testing.T) {
+	gear := mockGear(primitive.NewObjectID(), "Medpac", 400)
+	service := InitMockGearService(nil, nil, nil, nil, errors.New("test error"))
+
+	request, _ := json.Marshal(gear)
+
+	r, err := http.NewRequest("PUT", "/gear/"+gear.ID.Hex(), bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("UpdateGearByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("UpdateGearByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestGearService_DeleteGearByID_Success(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("DELETE", "/gear/"+primitive.NewObjectID().Hex(), nil)
+	if err != nil {
+		t.Errorf("DeleteGearByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("DeleteGearByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNoContent)
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 
@@ -16,16 +17,70 @@ import (
 type GearDatabase interface {
 	//Armor methods
 	InsertArmor(armor *model.Armor) error
-	GetArmor(query url.Values) ([]model.Armor, error)
+	GetArmor(query url.Values) ([]model.Armor, string, error)
+	CountArmor(query url.Values) (int64, error)
 	GetArmorByID(mongoID primitive.ObjectID) (*model.Armor, error)
 	UpdateArmorByID(armor model.Armor, mongoID primitive.ObjectID) error
 	DeleteArmorByID(mongoID primitive.ObjectID) error
 	//Weapon methods
 	InsertWeapon(weapon *model.Weapon) error
-	GetWeapon(query url.Values) ([]model.Weapon, error)
+	GetWeapon(query url.Values) ([]model.Weapon, string, error)
+	CountWeapon(query url.Values) (int64, error)
 	GetWeaponByID(mongoID primitive.ObjectID) (*model.Weapon, error)
 	UpdateWeaponByID(weapon model.Weapon, mongoID primitive.ObjectID) error
 	DeleteWeaponByID(mongoID primitive.ObjectID) error
+	//Attachment methods
+	InsertAttachment(attachment *model.Attachment) error
+	GetAttachment(query url.Values) ([]model.Attachment, error)
+	GetAttachmentByID(mongoID primitive.ObjectID) (*model.Attachment, error)
+	UpdateAttachmentByID(attachment model.Attachment, mongoID primitive.ObjectID) error
+	DeleteAttachmentByID(mongoID primitive.ObjectID) error
+	UpdateArmorAttachments(attachments, prior []model.InstalledAttachment, mongoID primitive.ObjectID) error
+	UpdateWeaponAttachments(attachments, prior []model.InstalledAttachment, mongoID primitive.ObjectID) error
+	CountAttachmentItems(attachmentID primitive.ObjectID) (int64, error)
+	//Gear methods
+	InsertGear(gear *model.Gear) error
+	GetGear(query url.Values) ([]model.Gear, error)
+	CountGear(query url.Values) (int64, error)
+	GetGearByID(mongoID primitive.ObjectID) (*model.Gear, error)
+	UpdateGearByID(gear model.Gear, mongoID primitive.ObjectID) error
+	DeleteGearByID(mongoID primitive.ObjectID) error
+	//Vehicle methods
+	InsertVehicle(vehicle *model.Vehicle) error
+	GetVehicle(query url.Values) ([]model.Vehicle, error)
+	GetVehicleByID(mongoID primitive.ObjectID) (*model.Vehicle, error)
+	UpdateVehicleByID(vehicle model.Vehicle, mongoID primitive.ObjectID) error
+	DeleteVehicleByID(mongoID primitive.ObjectID) error
+	//Inventory methods
+	GetInventory(characterID string) (*model.Inventory, error)
+	UpdateInventory(inventory model.Inventory) error
+	//Source methods
+	GetSourceCounts() ([]model.SourceCount, error)
+	//Crafting template methods
+	InsertTemplate(template *model.CraftingTemplate) error
+	GetTemplate(query url.Values) ([]model.CraftingTemplate, error)
+	GetTemplateByID(mongoID primitive.ObjectID) (*model.CraftingTemplate, error)
+	UpdateTemplateByID(template model.CraftingTemplate, mongoID primitive.ObjectID) error
+	DeleteTemplateByID(mongoID primitive.ObjectID) error
+	//Shop methods
+	GetShopByID(mongoID primitive.ObjectID) (*model.Shop, error)
+	SaveShop(shop model.Shop) error
+	DeleteShopByID(mongoID primitive.ObjectID) error
+	//Loot methods
+	InsertLootTable(table *model.LootTable) error
+	GetLootTable(query url.Values) ([]model.LootTable, error)
+	GetLootTableByID(mongoID primitive.ObjectID) (*model.LootTable, error)
+	UpdateLootTableByID(table model.LootTable, mongoID primitive.ObjectID) error
+	DeleteLootTableByID(mongoID primitive.ObjectID) error
+	//Instance methods
+	InsertInstance(instance *model.ItemInstance) error
+	GetInstance(query url.Values) ([]model.ItemInstance, error)
+	GetInstanceByID(mongoID primitive.ObjectID) (*model.ItemInstance, error)
+	UpdateInstanceByID(instance model.ItemInstance, mongoID primitive.ObjectID) error
+	DeleteInstanceByID(mongoID primitive.ObjectID) error
+	CountAttachmentInstances(attachmentID primitive.ObjectID) (int64, error)
+	//Search methods
+	Search(search model.SearchQuery, pageNumber, pageCount int) ([]model.SearchResult, int64, error)
 	//Helper methods
 	Ping() error
 }
@@ -34,6 +89,8 @@ type GearDatabase interface {
 type GearService struct {
 	Version  string
 	Database GearDatabase
+	//MaxPageSize caps the pageCount of paged lists, 0 uses api.DefaultMaxPageSize
+	MaxPageSize int
 }
 
 //Routes sets up the routes for the RESTful interface
@@ -57,6 +114,101 @@ func (s *GearService) Routes(r *mux.Router) *mux.Router {
 	r.HandleFunc("/armor/{ID}", s.DeleteArmorByID).Methods(http.MethodDelete)
 	r.HandleFunc("/weapon/{ID}", s.DeleteWeaponByID).Methods(http.MethodDelete)
 
+	//Attachments
+	r.HandleFunc("/attachment", s.InsertAttachment).Methods(http.MethodPost)
+	r.HandleFunc("/attachment", s.GetAttachment).Methods(http.MethodGet)
+	r.HandleFunc("/attachment/{ID}", s.GetAttachmentByID).Methods(http.MethodGet)
+	r.HandleFunc("/attachment/{ID}", s.UpdateAttachmentByID).Methods(http.MethodPut)
+	r.HandleFunc("/attachment/{ID}", s.DeleteAttachmentByID).Methods(http.MethodDelete)
+
+	r.HandleFunc("/armor/{ID}/attachments", s.GetArmorAttachments).Methods(http.MethodGet)
+	r.HandleFunc("/weapon/{ID}/attachments", s.GetWeaponAttachments).Methods(http.MethodGet)
+
+	r.HandleFunc("/armor/{ID}/attachments", s.InstallArmorAttachment).Methods(http.MethodPost)
+	r.HandleFunc("/weapon/{ID}/attachments", s.InstallWeaponAttachment).Methods(http.MethodPost)
+
+	r.HandleFunc("/armor/{ID}/attachments/{attachmentID}", s.RemoveArmorAttachment).Methods(http.MethodDelete)
+	r.HandleFunc("/weapon/{ID}/attachments/{attachmentID}", s.RemoveWeaponAttachment).Methods(http.MethodDelete)
+
+	//Gear
+	r.HandleFunc("/gear", s.InsertGear).Methods(http.MethodPost)
+	r.HandleFunc("/gear", s.GetGear).Methods(http.MethodGet)
+	r.HandleFunc("/gear/{ID}", s.GetGearByID).Methods(http.MethodGet)
+	r.HandleFunc("/gear/{ID}", s.UpdateGearByID).Methods(http.MethodPut)
+	r.HandleFunc("/gear/{ID}", s.DeleteGearByID).Methods(http.MethodDelete)
+
+	//Vehicles
+	r.HandleFunc("/vehicle", s.InsertVehicle).Methods(http.MethodPost)
+	r.HandleFunc("/vehicle", s.GetVehicle).Methods(http.MethodGet)
+	r.HandleFunc("/vehicle/{ID}", s.GetVehicleByID).Methods(http.MethodGet)
+	r.HandleFunc("/vehicle/{ID}", s.UpdateVehicleByID).Methods(http.MethodPut)
+	r.HandleFunc("/vehicle/{ID}", s.DeleteVehicleByID).Methods(http.MethodDelete)
+
+	//Inventory
+	r.HandleFunc("/inventory/{characterID}", s.GetInventory).Methods(http.MethodGet)
+	r.HandleFunc("/inventory/{characterID}", s.UpdateInventory).Methods(http.MethodPut)
+
+	//Crafting
+	r.HandleFunc("/template", s.InsertTemplate).Methods(http.MethodPost)
+	r.HandleFunc("/template", s.GetTemplate).Methods(http.MethodGet)
+	r.HandleFunc("/template/{ID}", s.GetTemplateByID).Methods(http.MethodGet)
+	r.HandleFunc("/template/{ID}", s.UpdateTemplateByID).Methods(http.MethodPut)
+	r.HandleFunc("/template/{ID}", s.DeleteTemplateByID).Methods(http.MethodDelete)
+	r.HandleFunc("/craft", s.Craft).Methods(http.MethodPost)
+
+	//Shops
+	r.HandleFunc("/shops/generate", s.GenerateShop).Methods(http.MethodPost)
+	r.HandleFunc("/shops/{ID}", s.GetShopByID).Methods(http.MethodGet)
+	r.HandleFunc("/shops/{ID}/regenerate", s.RegenerateShop).Methods(http.MethodPost)
+	r.HandleFunc("/shops/{ID}", s.DeleteShopByID).Methods(http.MethodDelete)
+
+	//Loot
+	r.HandleFunc("/loot", s.InsertLootTable).Methods(http.MethodPost)
+	r.HandleFunc("/loot", s.GetLootTable).Methods(http.MethodGet)
+	r.HandleFunc("/loot/{ID}", s.GetLootTableByID).Methods(http.MethodGet)
+	r.HandleFunc("/loot/{ID}", s.UpdateLootTableByID).Methods(http.MethodPut)
+	r.HandleFunc("/loot/{ID}", s.DeleteLootTableByID).Methods(http.MethodDelete)
+	r.HandleFunc("/loot/{ID}/roll", s.RollLootTable).Methods(http.MethodPost)
+
+	//Instances
+	r.HandleFunc("/instance", s.InsertInstance).Methods(http.MethodPost)
+	r.HandleFunc("/instance", s.GetInstance).Methods(http.MethodGet)
+	r.HandleFunc("/instance/{ID}", s.GetInstanceByID).Methods(http.MethodGet)
+	r.HandleFunc("/instance/{ID}", s.UpdateInstanceByID).Methods(http.MethodPut)
+	r.HandleFunc("/instance/{ID}", s.DeleteInstanceByID).Methods(http.MethodDelete)
+	r.HandleFunc("/instance/{ID}/stats", s.GetInstanceStats).Methods(http.MethodGet)
+
+	//Search
+	r.HandleFunc("/search", s.Search).Methods(http.MethodGet)
+
+	//V2
+	v2 := r.PathPrefix("/v2").Subrouter()
+	v2.HandleFunc("/armor", s.InsertArmorV2).Methods(http.MethodPost)
+	v2.HandleFunc("/weapon", s.InsertWeaponV2).Methods(http.MethodPost)
+	v2.HandleFunc("/armor", s.GetArmorV2).Methods(http.MethodGet)
+	v2.HandleFunc("/weapon", s.GetWeaponV2).Methods(http.MethodGet)
+	v2.HandleFunc("/armor/{ID}", s.GetArmorByIDV2).Methods(http.MethodGet)
+	v2.HandleFunc("/weapon/{ID}", s.GetWeaponByIDV2).Methods(http.MethodGet)
+	v2.HandleFunc("/armor/{ID}", s.UpdateArmorByIDV2).Methods(http.MethodPut)
+	v2.HandleFunc("/weapon/{ID}", s.UpdateWeaponByIDV2).Methods(http.MethodPut)
+	v2.HandleFunc("/armor/{ID}", s.DeleteArmorByID).Methods(http.MethodDelete)
+	v2.HandleFunc("/weapon/{ID}", s.DeleteWeaponByID).Methods(http.MethodDelete)
+
+	//Rules
+	r.HandleFunc("/encumbrance", s.CalculateEncumbrance).Methods(http.MethodPost)
+	r.HandleFunc("/armor/{ID}/availability", s.GetArmorAvailability).Methods(http.MethodGet)
+	r.HandleFunc("/weapon/{ID}/availability", s.GetWeaponAvailability).Methods(http.MethodGet)
+	r.HandleFunc("/armor/{ID}/price", s.GetArmorPrice).Methods(http.MethodGet)
+	r.HandleFunc("/weapon/{ID}/price", s.GetWeaponPrice).Methods(http.MethodGet)
+	r.HandleFunc("/weapon/{ID}/attack", s.RollWeaponAttack).Methods(http.MethodPost)
+	r.HandleFunc("/simulate", s.SimulateAttacks).Methods(http.MethodPost)
+
+	//Sources
+	r.HandleFunc("/sources", s.GetSources).Methods(http.MethodGet)
+
+	//Reports
+	r.HandleFunc("/reports/matrix", s.GetMatrixReport).Methods(http.MethodGet)
+
 	return r
 }
 
@@ -89,6 +241,20 @@ func (s *GearService) healthCheck(database GearDatabase) http.Handler {
 	})
 }
 
+//respondWithError responds with every invalid field for validation errors and falls back to api.CheckError otherwise
+func respondWithError(w http.ResponseWriter, err error) {
+	var validationErr model.ValidationError
+	if errors.As(err, &validationErr) {
+		api.RespondWithJSON(w, http.StatusBadRequest, model.ValidationErrorResponse{
+			Error:  validationErr.Error(),
+			Fields: validationErr,
+		})
+		return
+	}
+
+	api.RespondWithError(w, api.CheckError(err), err.Error())
+}
+
 //InsertArmor is the handler function for inserting an armor object
 func (s *GearService) InsertArmor(w http.ResponseWriter, r *http.Request) {
 	logrus.Infof("InsertArmor invoked with url: %v", r.URL)
@@ -103,6 +269,12 @@ func (s *GearService) InsertArmor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = armorModel.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
 	err = s.Database.InsertArmor(&armorModel)
 	if err != nil {
 		api.RespondWithError(w, api.CheckError(err), err.Error())
@@ -125,6 +297,13 @@ func (s *GearService) InsertWeapon(w http.ResponseWriter, r *http.Request) {
 		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
 		return
 	}
+
+	err = weaponModel.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
 	err = s.Database.InsertWeapon(&weaponModel)
 	if err != nil {
 		api.RespondWithError(w, api.CheckError(err), err.Error())
@@ -138,26 +317,58 @@ func (s *GearService) InsertWeapon(w http.ResponseWriter, r *http.Request) {
 func (s *GearService) GetArmor(w http.ResponseWriter, r *http.Request) {
 	logrus.Infof("GetArmor invoked with url: %v", r.URL)
 
-	armor, err := s.Database.GetArmor(r.URL.Query())
+	query, pageNumber, pageCount, err := api.ParsePage(r.URL.Query(), s.MaxPageSize)
 	if err != nil {
-		api.RespondWithError(w, api.CheckError(err), err.Error())
+		respondWithError(w, err)
 		return
 	}
 
-	api.RespondWithJSON(w, http.StatusOK, armor)
+	armor, nextCursor, err := s.Database.GetArmor(query)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	if armor == nil {
+		armor = []model.Armor{}
+	}
+
+	total, err := s.Database.CountArmor(query)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	page := model.NewPage(armor, total, pageNumber, pageCount).WithCursor(nextCursor, query.Get(api.CursorParam) != "")
+	api.RespondWithPage(w, r.URL, page)
 }
 
 //GetWeapon is the hanblder function to return all weapons in the database
 func (s *GearService) GetWeapon(w http.ResponseWriter, r *http.Request) {
 	logrus.Infof("GetWeapon invoked with url: %v", r.URL)
 
-	weapon, err := s.Database.GetWeapon(r.URL.Query())
+	query, pageNumber, pageCount, err := api.ParsePage(r.URL.Query(), s.MaxPageSize)
 	if err != nil {
-		api.RespondWithError(w, api.CheckError(err), err.Error())
+		respondWithError(w, err)
 		return
 	}
 
-	api.RespondWithJSON(w, http.StatusOK, weapon)
+	weapon, nextCursor, err := s.Database.GetWeapon(query)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	if weapon == nil {
+		weapon = []model.Weapon{}
+	}
+
+	total, err := s.Database.CountWeapon(query)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	page := model.NewPage(weapon, total, pageNumber, pageCount).WithCursor(nextCursor, query.Get(api.CursorParam) != "")
+	api.RespondWithPage(w, r.URL, page)
 }
 
 //GetArmorByID is the handler function to return a specific armor in the database
@@ -225,9 +436,15 @@ func (s *GearService) UpdateArmorByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = armor.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
 	err = s.Database.UpdateArmorByID(armor, objectID)
 	if err != nil {
-		api.RespondWithError(w, api.CheckError(err), err.Error())
+		respondWithError(w, err)
 		return
 	}
 
@@ -255,9 +472,15 @@ func (s *GearService) UpdateWeaponByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = weapon.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
 	err = s.Database.UpdateWeaponByID(weapon, objectID)
 	if err != nil {
-		api.RespondWithError(w, api.CheckError(err), err.Error())
+		respondWithError(w, err)
 		return
 	}
 
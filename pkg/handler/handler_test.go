@@ -11,6 +11,7 @@ import (
 	model "github.com/geeksheik9/gear-CRUD/models"
 	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
 	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -242,11 +243,18 @@ func TestGearService_GetArmor_Success(t *testing.T) {
 		t.Errorf("GetArmor() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
 	}
 
-	resp := []model.Armor{}
-	err = json.NewDecoder(w.Body).Decode(&resp)
+	page := struct {
+		Items []model.Armor `json:"items"`
+		Total int64         `json:"total"`
+	}{}
+	err = json.NewDecoder(w.Body).Decode(&page)
 	if err != nil {
 		t.Errorf("GetArmor() error:\n got: %v\n expected: <nil>", err)
 	}
+	if page.Total != 1 || len(page.Items) != 1 {
+		t.Fatalf("GetArmor() error:\ngot: %v items of %v\nexpected: 1 items of 1", len(page.Items), page.Total)
+	}
+	resp := page.Items
 	if resp[0].ID != armor.ID || resp[0].ArmorType != armor.ArmorType || resp[0].Price != armor.Price {
 		t.Errorf("GetArmor() error:\ngot: %v\nexpected: %v", resp[0], armor)
 	}
@@ -288,16 +296,115 @@ func TestGearService_GetWeapon_Success(t *testing.T) {
 		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
 	}
 
-	resp := []model.Weapon{}
-	err = json.NewDecoder(w.Body).Decode(&resp)
+	page := struct {
+		Items []model.Weapon `json:"items"`
+		Total int64          `json:"total"`
+	}{}
+	err = json.NewDecoder(w.Body).Decode(&page)
 	if err != nil {
 		t.Errorf("GetWeapon() error:\n got: %v\n expected: <nil>", err)
 	}
+	if page.Total != 1 || len(page.Items) != 1 {
+		t.Fatalf("GetWeapon() error:\ngot: %v items of %v\nexpected: 1 items of 1", len(page.Items), page.Total)
+	}
+	resp := page.Items
 	if resp[0].ID != weapon.ID || resp[0].WeaponType != weapon.WeaponType || resp[0].Price != weapon.Price {
 		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: %v", resp[0], weapon)
 	}
 }
 
+func TestGearService_GetArmor_Paged(t *testing.T) {
+	armors := mockArmor(mockSingleArmor(primitive.NewObjectID(), "test", 5))
+	service := InitMockGearService(nil, armors, nil, nil, nil)
+	service.Database.(*mocks.MockGearDatabase).CountToReturn = 25
+
+	r, err := http.NewRequest("GET", "/armor?pageNumber=2&pageCount=10&restricted=false", nil)
+	if err != nil {
+		t.Errorf("GetArmor() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("GetArmor() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+
+	page := model.Page{}
+	err = json.NewDecoder(w.Body).Decode(&page)
+	if err != nil {
+		t.Errorf("GetArmor() error:\ngot: %v\nexpected: <nil>", err)
+	}
+	if page.Total != 25 || page.PageNumber != 2 || page.PageSize != 10 || page.TotalPages != 3 || !page.HasNext {
+		t.Errorf("GetArmor() error:\ngot: %+v\nexpected: page 2 of 10 with 25 total and a next page", page)
+	}
+
+	expected := `</armor?pageCount=10&pageNumber=1&restricted=false>; rel="first", ` +
+		`</armor?pageCount=10&pageNumber=1&restricted=false>; rel="prev", ` +
+		`</armor?pageCount=10&pageNumber=3&restricted=false>; rel="next", ` +
+		`</armor?pageCount=10&pageNumber=3&restricted=false>; rel="last"`
+	if link := w.Header().Get("Link"); link != expected {
+		t.Errorf("GetArmor() error:\ngot: %v\nexpected: %v", link, expected)
+	}
+}
+
+func TestGearService_GetWeapon_PageCountTooLarge(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+	service.MaxPageSize = 50
+
+	r, err := http.NewRequest("GET", "/weapon?pageCount=51", nil)
+	if err != nil {
+		t.Errorf("GetWeapon() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetWeapon_Cursor(t *testing.T) {
+	weapons := mockWeapons(mockWeapon(primitive.NewObjectID(), "test", 5))
+	service := InitMockGearService(nil, nil, nil, weapons, nil)
+	db := service.Database.(*mocks.MockGearDatabase)
+	db.CountToReturn = 30
+	db.NextCursorToReturn = "next"
+
+	r, err := http.NewRequest("GET", "/weapon?cursor=current&pageCount=1", nil)
+	if err != nil {
+		t.Errorf("GetWeapon() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+	if db.LastQuery.Get("cursor") != "current" {
+		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: the cursor passed to the database", db.LastQuery)
+	}
+
+	page := model.Page{}
+	err = json.NewDecoder(w.Body).Decode(&page)
+	if err != nil {
+		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: <nil>", err)
+	}
+	if page.NextCursor != "next" || !page.HasNext {
+		t.Errorf("GetWeapon() error:\ngot: %+v\nexpected: nextCursor next", page)
+	}
+
+	expected := `</weapon?pageCount=1&pageNumber=1>; rel="first", </weapon?cursor=next&pageCount=1>; rel="next"`
+	if link := w.Header().Get("Link"); link != expected {
+		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: %v", link, expected)
+	}
+}
+
 func TestGearService_GetWeapon_DBError(t *testing.T) {
 	service := InitMockGearService(nil, nil, nil, nil, errors.New("test error"))
 
@@ -726,3 +833,191 @@ func TestGearService_DeleteWeaponByID_BadID(t *testing.T) {
 
 	}
 }
+
+func TestGearService_InsertWeapon_InvalidQuality(t *testing.T) {
+	id := primitive.NewObjectID()
+	weapon := mockWeapon(id, "test", 5)
+	weapon.Special = "Pierce 2, Sparkly 3"
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	request, _ := json.Marshal(weapon)
+
+	r, err := http.NewRequest("POST", "/weapon", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("InsertWeapon() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InsertWeapon() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "special" {
+		t.Errorf("InsertWeapon() error:\ngot: %v\nexpected: special field error", resp)
+	}
+}
+
+func TestGearService_UpdateArmorByID_InvalidQuality(t *testing.T) {
+	id := primitive.NewObjectID()
+	armor := mockSingleArmor(id, "test", 5)
+	armor.Qualities = []model.Quality{{Name: "Deflection"}}
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	request, _ := json.Marshal(armor)
+
+	r, err := http.NewRequest("PUT", "/armor/"+id.Hex(), bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("UpdateArmorByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateArmorByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetWeapon_ValidationError(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, model.ValidationError{{Field: "quality", Message: "unknown quality Sparkly"}})
+
+	r, err := http.NewRequest("GET", "/weapon?quality=Sparkly", nil)
+	if err != nil {
+		t.Errorf("GetWeapon() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GetWeapon() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_InsertWeapon_BadDamage(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("POST", "/weapon", bytes.NewBufferString(`{"name": "test", "damage": "lots"}`))
+	if err != nil {
+		t.Errorf("InsertWeapon() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InsertWeapon() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_UpdateWeaponByID_InvalidRange(t *testing.T) {
+	id := primitive.NewObjectID()
+	weapon := mockWeapon(id, "test", 5)
+	weapon.Range = "Planetary"
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	request, _ := json.Marshal(weapon)
+
+	r, err := http.NewRequest("PUT", "/weapon/"+id.Hex(), bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("UpdateWeaponByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateWeaponByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "range" {
+		t.Errorf("UpdateWeaponByID() error:\ngot: %v\nexpected: range field error", resp)
+	}
+}
+
+func TestGearService_UpdateWeaponByID_HardPointsInUse(t *testing.T) {
+	id := primitive.NewObjectID()
+	weapon := mockWeapon(id, "test", 5)
+	inUse := model.ValidationError{{Field: "hp", Message: "installed attachments use more than 0 hard points, remove attachments first"}}
+	service := InitMockGearService(nil, nil, nil, nil, inUse)
+
+	request, _ := json.Marshal(weapon)
+
+	r, err := http.NewRequest("PUT", "/weapon/"+id.Hex(), bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("UpdateWeaponByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "hp" {
+		t.Errorf("UpdateWeaponByID() error:\ngot: %v %+v\nexpected: %v with an hp error", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_UpdateWeaponByID_ClearQualities(t *testing.T) {
+	id := primitive.NewObjectID()
+	db := &mocks.MockGearDatabase{}
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"name": "DL-44", "damage": {"base": 7}, "range": "Medium", "special": "", "qualities": []}`
+	r, err := http.NewRequest("PUT", "/weapon/"+id.Hex(), bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("UpdateWeaponByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || db.UpdatedWeapon == nil {
+		t.Fatalf("UpdateWeaponByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+
+	// the update sets the whole document, the cleared qualities must be part of it to replace the stored ones
+	document, err := bson.Marshal(db.UpdatedWeapon)
+	if err != nil {
+		t.Fatalf("UpdateWeaponByID() error:\ngot: %v\nexpected: <no error>", err)
+	}
+	qualities, ok := bson.Raw(document).Lookup("qualities").ArrayOK()
+	if values, _ := qualities.Values(); !ok || len(values) != 0 {
+		t.Errorf("UpdateWeaponByID() error:\ngot: %v\nexpected: an empty qualities array", document)
+	}
+}
+
+func TestGearService_GetWeaponByID_Restricted(t *testing.T) {
+	id := primitive.NewObjectID()
+	weapon := mockWeapon(id, "test", 5)
+	weapon.Restricted = true
+	service := InitMockGearService(nil, nil, &weapon, nil, nil)
+
+	r, err := http.NewRequest("GET", "/weapon/"+id.Hex(), nil)
+	if err != nil {
+		t.Errorf("GetWeaponByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := map[string]interface{}{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || resp["restricted"] != true {
+		t.Errorf("GetWeaponByID() error:\ngot: %v\nexpected: restricted true", resp)
+	}
+}
@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//InsertInstance is the handler function for inserting a item instance
+func (s *GearService) InsertInstance(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertInstance invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	var instanceModel model.ItemInstance
+	instanceModel.ID = primitive.NewObjectID()
+
+	err := json.NewDecoder(r.Body).Decode(&instanceModel)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = s.validateInstance(&instanceModel)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.InsertInstance(&instanceModel)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, "Instance Object Created")
+}
+
+//GetInstance is the handler function to return all item instances in the database
+func (s *GearService) GetInstance(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetInstance invoked with url: %v", r.URL)
+
+	instances, err := s.Database.GetInstance(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, instances)
+}
+
+//GetInstanceByID is the handler function to return a specific item instance in the database
+func (s *GearService) GetInstanceByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetInstanceByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := api.StringToObjectID(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	instance, err := s.Database.GetInstanceByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, instance)
+}
+
+//UpdateInstanceByID is the handler function to update a specific item instance in the database
+func (s *GearService) UpdateInstanceByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateInstanceByID invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	instance := model.ItemInstance{}
+	err = json.NewDecoder(r.Body).Decode(&instance)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	instance.ID = objectID
+
+	err = s.validateInstance(&instance)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.UpdateInstanceByID(instance, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, objectID)
+}
+
+//DeleteInstanceByID is the handler function to remove a specific item instance in the database
+func (s *GearService) DeleteInstanceByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("DeleteInstanceByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.DeleteInstanceByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+//GetInstanceStats is the handler function to return the effective stats of an item instance with its attachments and modifications applied
+func (s *GearService) GetInstanceStats(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetInstanceStats invoked with url: %v", r.URL)
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	instance, err := s.Database.GetInstanceByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	attachments, err := s.instanceAttachments(instance)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	modifiers, warnings, err := instance.Modifiers(attachments)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	var stats model.EffectiveStats
+	switch instance.Kind {
+	case model.WeaponKind:
+		weapon, err := s.Database.GetWeaponByID(instance.ItemID)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), err.Error())
+			return
+		}
+		modifiers, warnings, err = s.withCatalogModifiers(weapon.Attachments, modifiers, warnings)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), err.Error())
+			return
+		}
+		stats = instance.WeaponStats(*weapon, modifiers)
+	case model.ArmorKind:
+		armor, err := s.Database.GetArmorByID(instance.ItemID)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), err.Error())
+			return
+		}
+		modifiers, warnings, err = s.withCatalogModifiers(armor.Attachments, modifiers, warnings)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), err.Error())
+			return
+		}
+		stats = instance.ArmorStats(*armor, modifiers)
+	default:
+		api.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("instance %v has unsupported kind %v", instance.ID.Hex(), instance.Kind))
+		return
+	}
+
+	stats.Warnings = warnings
+
+	api.RespondWithJSON(w, http.StatusOK, stats)
+}
+
+// validateInstance checks the instance and that its catalog item and attachments exist,
+// the attachments must fit in the hard points the catalog item has left free
+func (s *GearService) validateInstance(instance *model.ItemInstance) error {
+	err := instance.Validate()
+	if err != nil {
+		return err
+	}
+
+	var hardPoints int64
+	switch instance.Kind {
+	case model.WeaponKind:
+		var weapon *model.Weapon
+		weapon, err = s.Database.GetWeaponByID(instance.ItemID)
+		if err == nil {
+			hardPoints = weapon.HP - model.UsedHardPoints(weapon.Attachments)
+		}
+	case model.ArmorKind:
+		var armor *model.Armor
+		armor, err = s.Database.GetArmorByID(instance.ItemID)
+		if err == nil {
+			hardPoints = armor.HardPoints - model.UsedHardPoints(armor.Attachments)
+		}
+	}
+	if err != nil {
+		if api.CheckError(err) != http.StatusNotFound {
+			return err
+		}
+		validationErr := model.ValidationError{}
+		validationErr.Add("itemId", fmt.Sprintf("%v %v does not exist", instance.Kind, instance.ItemID.Hex()))
+		return validationErr
+	}
+
+	attachments, err := s.instanceAttachments(instance)
+	if err != nil {
+		return err
+	}
+
+	return instance.ValidateAttachments(hardPoints, attachments)
+}
+
+// withCatalogModifiers puts the base modifiers of the attachments installed on the catalog item before the instance modifiers
+// and adds the warnings about catalog attachments that no longer exist
+func (s *GearService) withCatalogModifiers(installed []model.InstalledAttachment, modifiers []model.Modifier, warnings []string) ([]model.Modifier, []string, error) {
+	attachments, err := s.catalogAttachments(installed)
+	if err != nil {
+		return nil, nil, err
+	}
+	catalogModifiers, catalogWarnings := model.CatalogModifiers(installed, attachments)
+	return append(catalogModifiers, modifiers...), append(catalogWarnings, warnings...), nil
+}
+
+// instanceAttachments looks up the attachments installed on the instance, attachments missing from the catalog are left out
+func (s *GearService) instanceAttachments(instance *model.ItemInstance) (map[primitive.ObjectID]*model.Attachment, error) {
+	installed := []model.InstalledAttachment{}
+	for _, instanceAttachment := range instance.Attachments {
+		installed = append(installed, instanceAttachment.InstalledAttachment)
+	}
+	return s.catalogAttachments(installed)
+}
+
+// catalogAttachments looks up the installed attachments in the catalog, attachments missing from the catalog are left out
+func (s *GearService) catalogAttachments(installed []model.InstalledAttachment) (map[primitive.ObjectID]*model.Attachment, error) {
+	attachments := map[primitive.ObjectID]*model.Attachment{}
+	for _, entry := range installed {
+		if _, ok := attachments[entry.AttachmentID]; ok {
+			continue
+		}
+
+		attachment, err := s.Database.GetAttachmentByID(entry.AttachmentID)
+		if err != nil {
+			if api.CheckError(err) == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		attachments[entry.AttachmentID] = attachment
+	}
+	return attachments, nil
+}
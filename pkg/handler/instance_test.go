@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func instanceDatabase() (*mocks.MockGearDatabase, model.Weapon, model.Attachment) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Heavy Blaster Pistol", 700)
+	weapon.Damage = model.Damage{Base: 7}
+	weapon.Critical = 3
+	weapon.HP = 3
+	attachment := model.Attachment{
+		ID:                  primitive.NewObjectID(),
+		Name:                "Augmented Trigger",
+		HardPoints:          2,
+		AllowedKinds:        []model.ItemKind{model.WeaponKind},
+		ModificationOptions: []model.ModificationOption{{Modifier: model.Modifier{Damage: 1}, Count: 2}},
+	}
+
+	return &mocks.MockGearDatabase{
+		WeaponByID:     map[primitive.ObjectID]*model.Weapon{weapon.ID: &weapon},
+		AttachmentByID: map[primitive.ObjectID]*model.Attachment{attachment.ID: &attachment},
+	}, weapon, attachment
+}
+
+func TestGearService_InsertInstance_Success(t *testing.T) {
+	db, weapon, attachment := instanceDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"characterId": "char-42", "kind": "weapon", "itemId": "` + weapon.ID.Hex() + `", "attachments": [{"attachmentId": "` + attachment.ID.Hex() + `", "modifications": [{"option": 0, "count": 2}]}]}`
+	r, err := http.NewRequest("POST", "/instance", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("InsertInstance() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || db.InsertedInstance == nil || db.InsertedInstance.Attachments[0].Name != "Augmented Trigger" {
+		t.Errorf("InsertInstance() error:\ngot: %v %+v\nexpected: %v with the attachment recorded", w.Code, db.InsertedInstance, http.StatusOK)
+	}
+}
+
+func TestGearService_InsertInstance_MissingItem(t *testing.T) {
+	db, _, _ := instanceDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"characterId": "char-42", "kind": "weapon", "itemId": "` + primitive.NewObjectID().Hex() + `"}`
+	r, err := http.NewRequest("POST", "/instance", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("InsertInstance() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "itemId" {
+		t.Errorf("InsertInstance() error:\ngot: %v %+v\nexpected: %v with an itemId error", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetInstanceStats_Success(t *testing.T) {
+	db, weapon, attachment := instanceDatabase()
+	db.InstanceToReturn = &model.ItemInstance{
+		ID:          primitive.NewObjectID(),
+		CharacterID: "char-42",
+		Kind:        model.WeaponKind,
+		ItemID:      weapon.ID,
+		CustomName:  "Han's Blaster",
+		Attachments: []model.InstanceAttachment{{
+			InstalledAttachment: attachment.Installed(),
+			Modifications:       []model.CompletedModification{{Option: 0, Count: 2}},
+		}},
+	}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/instance/"+db.InstanceToReturn.ID.Hex()+"/stats", nil)
+	if err != nil {
+		t.Errorf("GetInstanceStats() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.EffectiveStats{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.Name != "Han's Blaster" || resp.Damage == nil || resp.Damage.Base != 9 || resp.Critical != 3 || resp.UsedHardPoints != 2 {
+		t.Errorf("GetInstanceStats() error:\ngot: %v %+v\nexpected: %v with damage 9", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_GetInstanceStats_CatalogAttachments(t *testing.T) {
+	db, weapon, attachment := instanceDatabase()
+	grip := model.Attachment{ID: primitive.NewObjectID(), Name: "Custom Grip", HardPoints: 1, BaseModifier: model.Modifier{Critical: -1}}
+	db.AttachmentByID[grip.ID] = &grip
+	weapon.Attachments = []model.InstalledAttachment{grip.Installed()}
+	db.WeaponByID[weapon.ID] = &weapon
+	db.InstanceToReturn = &model.ItemInstance{
+		ID:          primitive.NewObjectID(),
+		Kind:        model.WeaponKind,
+		ItemID:      weapon.ID,
+		Attachments: []model.InstanceAttachment{{InstalledAttachment: attachment.Installed()}},
+	}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/instance/"+db.InstanceToReturn.ID.Hex()+"/stats", nil)
+	if err != nil {
+		t.Errorf("GetInstanceStats() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.EffectiveStats{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.Critical != 2 || resp.UsedHardPoints != 3 || len(resp.Modifiers) != 2 || resp.Modifiers[0].Description != "Custom Grip" {
+		t.Errorf("GetInstanceStats() error:\ngot: %v %+v\nexpected: %v with the custom grip applied", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_GetInstanceStats_MissingAttachment(t *testing.T) {
+	db, weapon, attachment := instanceDatabase()
+	delete(db.AttachmentByID, attachment.ID)
+	db.InstanceToReturn = &model.ItemInstance{
+		ID:          primitive.NewObjectID(),
+		Kind:        model.WeaponKind,
+		ItemID:      weapon.ID,
+		Attachments: []model.InstanceAttachment{{InstalledAttachment: attachment.Installed()}},
+	}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/instance/"+db.InstanceToReturn.ID.Hex()+"/stats", nil)
+	if err != nil {
+		t.Errorf("GetInstanceStats() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.EffectiveStats{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.Damage == nil || resp.Damage.Base != 7 || len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], attachment.Name) {
+		t.Errorf("GetInstanceStats() error:\ngot: %v %+v\nexpected: %v with a warning naming the missing attachment", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_UpdateInstanceByID_ClearAttachments(t *testing.T) {
+	db, weapon, _ := instanceDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"characterId": "char-42", "kind": "weapon", "itemId": "` + weapon.ID.Hex() + `", "customName": "", "attachments": []}`
+	r, err := http.NewRequest("PUT", "/instance/"+primitive.NewObjectID().Hex(), bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("UpdateInstanceByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || db.UpdatedInstance == nil {
+		t.Fatalf("UpdateInstanceByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+
+	// the update sets the whole document, the cleared fields must be part of it to replace the stored ones
+	document, err := bson.Marshal(db.UpdatedInstance)
+	if err != nil {
+		t.Fatalf("UpdateInstanceByID() error:\ngot: %v\nexpected: <no error>", err)
+	}
+	attachments, ok := bson.Raw(document).Lookup("attachments").ArrayOK()
+	if values, _ := attachments.Values(); !ok || len(values) != 0 {
+		t.Errorf("UpdateInstanceByID() error:\ngot: %v\nexpected: an empty attachments array", document)
+	}
+	for _, field := range []string{"customName", "notes"} {
+		if _, err := bson.Raw(document).LookupErr(field); err != nil {
+			t.Errorf("UpdateInstanceByID() error:\ngot: %v\nexpected: %v to be set", document, field)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+//GetInventory is the handler function to return the inventory of a character
+func (s *GearService) GetInventory(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetInventory invoked with url: %v", r.URL)
+
+	characterID := mux.Vars(r)["characterID"]
+
+	inventory, err := s.Database.GetInventory(characterID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("expand") == "items" {
+		err = s.expandInventory(inventory)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), err.Error())
+			return
+		}
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, inventory)
+}
+
+//UpdateInventory is the handler function to replace the inventory of a character
+func (s *GearService) UpdateInventory(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateInventory invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	inventory := model.Inventory{}
+	err := json.NewDecoder(r.Body).Decode(&inventory)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+	inventory.CharacterID = mux.Vars(r)["characterID"]
+	if inventory.Items == nil {
+		inventory.Items = []model.InventoryItem{}
+	}
+
+	err = inventory.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.validateInventoryItems(inventory.Items)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.UpdateInventory(inventory)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("expand") != "items" {
+		for i := range inventory.Items {
+			item := &inventory.Items[i]
+			item.Weapon, item.Armor, item.Gear = nil, nil, nil
+		}
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, inventory)
+}
+
+// validateInventoryItems checks that every item references a document in the catalog, expanding the items in place
+func (s *GearService) validateInventoryItems(items []model.InventoryItem) error {
+	validationErr := model.ValidationError{}
+
+	for i := range items {
+		err := s.expandItem(&items[i])
+		if err == nil {
+			continue
+		}
+		if api.CheckError(err) != http.StatusNotFound {
+			return err
+		}
+		validationErr.Add(fmt.Sprintf("items[%d].itemId", i), fmt.Sprintf("%v %v does not exist", items[i].Kind, items[i].ItemID.Hex()))
+	}
+
+	return validationErr.OrNil()
+}
+
+// expandInventory inlines the referenced catalog documents, items removed from the catalog are left unexpanded
+func (s *GearService) expandInventory(inventory *model.Inventory) error {
+	for i := range inventory.Items {
+		err := s.expandItem(&inventory.Items[i])
+		if err != nil {
+			if api.CheckError(err) == http.StatusNotFound {
+				logrus.Warnf("Inventory %v references missing %v %v", inventory.CharacterID, inventory.Items[i].Kind, inventory.Items[i].ItemID.Hex())
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandItem looks up the catalog document an inventory item references
+func (s *GearService) expandItem(item *model.InventoryItem) error {
+	var err error
+
+	switch item.Kind {
+	case model.WeaponKind:
+		item.Weapon, err = s.Database.GetWeaponByID(item.ItemID)
+	case model.ArmorKind:
+		item.Armor, err = s.Database.GetArmorByID(item.ItemID)
+	case model.GearKind:
+		item.Gear, err = s.Database.GetGearByID(item.ItemID)
+	default:
+		err = fmt.Errorf("%v not found, unknown item kind", item.Kind)
+	}
+
+	return err
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGearService_UpdateInventory_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	armor := mockSingleArmor(primitive.NewObjectID(), "Padded Armor", 500)
+	db := &mocks.MockGearDatabase{
+		WeaponByID: map[primitive.ObjectID]*model.Weapon{weapon.ID: &weapon},
+		ArmorByID:  map[primitive.ObjectID]*model.Armor{armor.ID: &armor},
+	}
+	service := GearService{Version: "test", Database: db}
+
+	inventory := model.Inventory{Items: []model.InventoryItem{
+		{Kind: model.WeaponKind, ItemID: weapon.ID, Quantity: 2, State: "Equipped"},
+		{Kind: model.ArmorKind, ItemID: armor.ID, Quantity: 1},
+	}}
+	request, _ := json.Marshal(inventory)
+
+	r, err := http.NewRequest("PUT", "/inventory/char-42?expand=items", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("UpdateInventory() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("UpdateInventory() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+
+	stored := db.UpdatedInventory
+	if stored == nil || stored.CharacterID != "char-42" || stored.Items[0].State != model.Equipped || stored.Items[1].State != model.Carried {
+		t.Errorf("UpdateInventory() error:\ngot: %+v\nexpected: char-42 with equipped and carried items", stored)
+	}
+
+	resp := model.Inventory{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || resp.Items[0].Weapon == nil || resp.Items[1].Armor == nil {
+		t.Errorf("UpdateInventory() error:\ngot: %+v\nexpected: expanded items", resp)
+	}
+}
+
+func TestGearService_UpdateInventory_MissingItem(t *testing.T) {
+	db := &mocks.MockGearDatabase{WeaponByID: map[primitive.ObjectID]*model.Weapon{}}
+	service := GearService{Version: "test", Database: db}
+
+	inventory := model.Inventory{Items: []model.InventoryItem{
+		{Kind: model.WeaponKind, ItemID: primitive.NewObjectID(), Quantity: 1},
+	}}
+	request, _ := json.Marshal(inventory)
+
+	r, err := http.NewRequest("PUT", "/inventory/char-42", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("UpdateInventory() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateInventory() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+	if db.UpdatedInventory != nil {
+		t.Errorf("UpdateInventory() error:\ngot: %v\nexpected: no update", db.UpdatedInventory)
+	}
+}
+
+func TestGearService_UpdateInventory_Invalid(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("PUT", "/inventory/char-42", bytes.NewBufferString(`{"items": [{"kind": "droid", "quantity": 0, "state": "lost"}]}`))
+	if err != nil {
+		t.Errorf("UpdateInventory() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 4 {
+		t.Errorf("UpdateInventory() error:\ngot: %v %v\nexpected: %v with kind, itemId, quantity and state errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetInventory_Success(t *testing.T) {
+	inventory := model.Inventory{CharacterID: "char-42", Items: []model.InventoryItem{
+		{Kind: model.WeaponKind, ItemID: primitive.NewObjectID(), Quantity: 1, State: model.Stowed},
+	}}
+	db := &mocks.MockGearDatabase{InventoryToReturn: &inventory}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/inventory/char-42", nil)
+	if err != nil {
+		t.Errorf("GetInventory() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.Inventory{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.CharacterID != "char-42" || len(resp.Items) != 1 || resp.Items[0].Weapon != nil {
+		t.Errorf("GetInventory() error:\ngot: %v %+v\nexpected: unexpanded char-42 inventory", w.Code, resp)
+	}
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/geeksheik9/gear-CRUD/pkg/loot"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const seedParam = "seed"
+
+//InsertLootTable is the handler function for inserting a loot table
+func (s *GearService) InsertLootTable(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertLootTable invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	var tableModel model.LootTable
+	tableModel.ID = primitive.NewObjectID()
+
+	err := json.NewDecoder(r.Body).Decode(&tableModel)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = tableModel.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.InsertLootTable(&tableModel)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, "Loot Table Object Created")
+}
+
+//GetLootTable is the handler function to return all loot tables in the database
+func (s *GearService) GetLootTable(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetLootTable invoked with url: %v", r.URL)
+
+	tables, err := s.Database.GetLootTable(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, tables)
+}
+
+//GetLootTableByID is the handler function to return a specific loot table in the database
+func (s *GearService) GetLootTableByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetLootTableByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := api.StringToObjectID(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	table, err := s.Database.GetLootTableByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, table)
+}
+
+//UpdateLootTableByID is the handler function to update a specific loot table in the database
+func (s *GearService) UpdateLootTableByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateLootTableByID invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	table := model.LootTable{}
+	err = json.NewDecoder(r.Body).Decode(&table)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	table.ID = objectID
+
+	err = table.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.UpdateLootTableByID(table, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, objectID)
+}
+
+//DeleteLootTableByID is the handler function to remove a specific loot table in the database
+func (s *GearService) DeleteLootTableByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("DeleteLootTableByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.DeleteLootTableByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+//RollLootTable is the handler function to roll on a loot table, the body optionally names the adversary and location
+//the loot is dropped for and the seed query parameter makes the roll repeatable
+func (s *GearService) RollLootTable(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("RollLootTable invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := api.StringToObjectID(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	request := model.LootRollRequest{}
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && err != io.EOF {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	validationErr := model.ValidationError{}
+	seed := time.Now().UnixNano()
+	if r.URL.Query().Get(seedParam) != "" {
+		seed = intParam(r.URL.Query(), seedParam, &validationErr)
+	}
+	validationErr.Merge("", request.Validate())
+	err = validationErr.OrNil()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	table, err := s.Database.GetLootTableByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	result, err := loot.Roll(r.Context(), s.Database, table, request, seed)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, result)
+}
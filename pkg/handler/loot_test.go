@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func lootDatabase() (*mocks.MockGearDatabase, model.LootTable) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	table := model.LootTable{ID: primitive.NewObjectID(), Name: "Thug", Rolls: 2, Entries: []model.LootEntry{
+		{Kind: model.WeaponKind, ItemID: &weapon.ID, Weight: 1, MinQuantity: 1, MaxQuantity: 1},
+	}}
+
+	return &mocks.MockGearDatabase{
+		WeaponByID:    map[primitive.ObjectID]*model.Weapon{weapon.ID: &weapon},
+		LootTableByID: map[primitive.ObjectID]*model.LootTable{table.ID: &table},
+	}, table
+}
+
+func TestGearService_InsertLootTable_Invalid(t *testing.T) {
+	db, _ := lootDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/loot", bytes.NewBufferString(`{"name": "Empty"}`))
+	if err != nil {
+		t.Errorf("InsertLootTable() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest || db.InsertedLootTable != nil {
+		t.Errorf("InsertLootTable() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_RollLootTable_Success(t *testing.T) {
+	db, table := lootDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/loot/"+table.ID.Hex()+"/roll?seed=5", bytes.NewBufferString(`{"adversary": "Rival"}`))
+	if err != nil {
+		t.Errorf("RollLootTable() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.LootRoll{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.Seed != 5 || len(resp.Items) != 1 || resp.Items[0].Quantity != 2 || resp.Items[0].Name != "Blaster Pistol" {
+		t.Errorf("RollLootTable() error:\ngot: %v %+v\nexpected: %v with two blaster pistols", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_RollLootTable_InvalidSeed(t *testing.T) {
+	db, table := lootDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/loot/"+table.ID.Hex()+"/roll?seed=abc", bytes.NewBufferString(""))
+	if err != nil {
+		t.Errorf("RollLootTable() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("RollLootTable() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_RollLootTable_NotFound(t *testing.T) {
+	db, _ := lootDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/loot/"+primitive.NewObjectID().Hex()+"/roll", bytes.NewBufferString(""))
+	if err != nil {
+		t.Errorf("RollLootTable() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("RollLootTable() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/geeksheik9/gear-CRUD/pkg/pricing"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	marketParam         = "market"
+	planetModifierParam = "planetModifier"
+	successesParam      = "successes"
+	advantagesParam     = "advantages"
+)
+
+//GetArmorPrice is the handler function to quote the buy and sell price of an armor
+func (s *GearService) GetArmorPrice(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetArmorPrice invoked with url: %v", r.URL)
+
+	options, err := pricingOptions(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	armor, err := s.Database.GetArmorByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	quote, err := pricing.Calculate(armor.Price, armor.Restricted, options)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, quote)
+}
+
+//GetWeaponPrice is the handler function to quote the buy and sell price of a weapon
+func (s *GearService) GetWeaponPrice(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetWeaponPrice invoked with url: %v", r.URL)
+
+	options, err := pricingOptions(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	quote, err := pricing.Calculate(weapon.Price, weapon.Restricted, options)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, quote)
+}
+
+// pricingOptions reads the market, planet modifier and Negotiation check results from the query
+func pricingOptions(query url.Values) (pricing.Options, error) {
+	options := pricing.Options{Market: pricing.Market(query.Get(marketParam))}
+	validationErr := model.ValidationError{}
+
+	if value := query.Get(planetModifierParam); value != "" {
+		modifier, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			validationErr.Add(planetModifierParam, fmt.Sprintf("%v is not a number", value))
+		}
+		options.PlanetModifier = modifier
+	}
+
+	options.Negotiation.Successes = intParam(query, successesParam, &validationErr)
+	options.Negotiation.Advantages = intParam(query, advantagesParam, &validationErr)
+
+	if len(validationErr) > 0 {
+		return options, validationErr
+	}
+	return options, options.Validate()
+}
+
+// intParam reads an optional whole number query parameter, recording an invalid value against the parameter
+func intParam(query url.Values, param string, validationErr *model.ValidationError) int64 {
+	value := query.Get(param)
+	if value == "" {
+		return 0
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		validationErr.Add(param, fmt.Sprintf("%v is not a whole number", value))
+	}
+	return parsed
+}
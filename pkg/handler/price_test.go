@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/pricing"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGearService_GetWeaponPrice_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	service := InitMockGearService(nil, nil, &weapon, nil, nil)
+
+	r, err := http.NewRequest("GET", "/weapon/"+weapon.ID.Hex()+"/price?market=blackMarket&planetModifier=1.5&successes=2", nil)
+	if err != nil {
+		t.Errorf("GetWeaponPrice() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := pricing.Quote{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil {
+		t.Errorf("GetWeaponPrice() error:\ngot: %v %v\nexpected: %v", w.Code, err, http.StatusOK)
+	}
+	if resp.ListPrice != 1200 || resp.BuyPrice != 1080 || resp.SellPrice != 330 {
+		t.Errorf("GetWeaponPrice() error:\ngot: %+v\nexpected: list 1200, buy 1080, sell 330", resp)
+	}
+}
+
+func TestGearService_GetArmorPrice_Restricted(t *testing.T) {
+	armor := mockSingleArmor(primitive.NewObjectID(), "Laminate", 2500)
+	armor.Restricted = true
+	service := InitMockGearService(&armor, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("GET", "/armor/"+armor.ID.Hex()+"/price", nil)
+	if err != nil {
+		t.Errorf("GetArmorPrice() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := pricing.Quote{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp.Market != pricing.Restricted || resp.BuyPrice != 10000 {
+		t.Errorf("GetArmorPrice() error:\ngot: %v %+v\nexpected: %v restricted quote", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_GetArmorPrice_InvalidQuery(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("GET", "/armor/"+primitive.NewObjectID().Hex()+"/price?planetModifier=far&successes=two", nil)
+	if err != nil {
+		t.Errorf("GetArmorPrice() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 2 {
+		t.Errorf("GetArmorPrice() error:\ngot: %v %+v\nexpected: %v with planetModifier and successes errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetWeaponPrice_PlanetModifierOutOfRange(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	service := InitMockGearService(nil, nil, &weapon, nil, nil)
+
+	r, err := http.NewRequest("GET", "/weapon/"+weapon.ID.Hex()+"/price?planetModifier=1e300", nil)
+	if err != nil {
+		t.Errorf("GetWeaponPrice() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "planetModifier" {
+		t.Errorf("GetWeaponPrice() error:\ngot: %v %+v\nexpected: %v with a planetModifier error", w.Code, resp, http.StatusBadRequest)
+	}
+}
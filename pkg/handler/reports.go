@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/geeksheik9/gear-CRUD/pkg/combat"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	weaponsParam     = "weapons"
+	armorParam       = "armor"
+	brawnParam       = "brawn"
+	targetBrawnParam = "targetBrawn"
+	formatParam      = "format"
+	csvFormat        = "csv"
+	csvContentType   = "text/csv"
+	maxMatrixItems   = 50
+)
+
+//GetMatrixReport is the handler function to compare every requested weapon against every requested armor as JSON or CSV
+func (s *GearService) GetMatrixReport(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetMatrixReport invoked with url: %v", r.URL)
+
+	query := r.URL.Query()
+	validationErr := model.ValidationError{}
+	weaponIDs := objectIDsParam(query, weaponsParam, &validationErr)
+	armorIDs := objectIDsParam(query, armorParam, &validationErr)
+	brawn := intParam(query, brawnParam, &validationErr)
+	targetBrawn := intParam(query, targetBrawnParam, &validationErr)
+	if brawn < 0 {
+		validationErr.Add(brawnParam, "must not be negative")
+	}
+	if targetBrawn < 0 {
+		validationErr.Add(targetBrawnParam, "must not be negative")
+	}
+	if len(validationErr) > 0 {
+		respondWithError(w, validationErr)
+		return
+	}
+
+	weapons := make([]model.Weapon, 0, len(weaponIDs))
+	for _, weaponID := range weaponIDs {
+		weapon, err := s.Database.GetWeaponByID(weaponID)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), fmt.Sprintf("weapon %v: %v", weaponID.Hex(), err.Error()))
+			return
+		}
+		weapons = append(weapons, *weapon)
+	}
+
+	armors := make([]model.Armor, 0, len(armorIDs))
+	for _, armorID := range armorIDs {
+		armor, err := s.Database.GetArmorByID(armorID)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), fmt.Sprintf("armor %v: %v", armorID.Hex(), err.Error()))
+			return
+		}
+		armors = append(armors, *armor)
+	}
+
+	matrix := combat.BuildMatrix(weapons, armors, brawn, targetBrawn)
+
+	if query.Get(formatParam) == csvFormat || strings.Contains(r.Header.Get("Accept"), csvContentType) {
+		respondWithCSV(w, "matrix.csv", matrix.Records())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, matrix)
+}
+
+// objectIDsParam reads a required list of object IDs given comma separated or as repeated query parameters
+func objectIDsParam(query url.Values, param string, validationErr *model.ValidationError) []primitive.ObjectID {
+	objectIDs := []primitive.ObjectID{}
+
+	for _, value := range query[param] {
+		for _, ID := range strings.Split(value, ",") {
+			ID = strings.TrimSpace(ID)
+			if ID == "" {
+				continue
+			}
+			objectID, err := api.StringToObjectID(ID)
+			if err != nil {
+				validationErr.Add(param, fmt.Sprintf("%v is not a valid objectID", ID))
+				continue
+			}
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+
+	if len(objectIDs) == 0 {
+		validationErr.Add(param, "at least one ID is required")
+	}
+	if len(objectIDs) > maxMatrixItems {
+		validationErr.Add(param, fmt.Sprintf("at most %v IDs are allowed", maxMatrixItems))
+	}
+
+	return objectIDs
+}
+
+// respondWithCSV writes the records as a CSV attachment
+func respondWithCSV(w http.ResponseWriter, filename string, records [][]string) {
+	w.Header().Set("Content-Type", csvContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	err := writer.WriteAll(records)
+	if err != nil {
+		logrus.Errorf("Error writing CSV response: %v", err)
+	}
+}
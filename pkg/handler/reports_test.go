@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/combat"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func matrixService() (GearService, model.Weapon, model.Armor, model.Armor) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	weapon.Damage = model.Damage{Base: 6}
+	padded := mockSingleArmor(primitive.NewObjectID(), "Padded Armor", 500)
+	padded.Soak = 2
+	laminate := mockSingleArmor(primitive.NewObjectID(), "Laminate", 2500)
+	laminate.Soak = 2
+	laminate.Defense = 1
+
+	db := &mocks.MockGearDatabase{
+		WeaponByID: map[primitive.ObjectID]*model.Weapon{weapon.ID: &weapon},
+		ArmorByID:  map[primitive.ObjectID]*model.Armor{padded.ID: &padded, laminate.ID: &laminate},
+	}
+	return GearService{Version: "test", Database: db}, weapon, padded, laminate
+}
+
+func TestGearService_GetMatrixReport_JSON(t *testing.T) {
+	service, weapon, padded, laminate := matrixService()
+
+	r, err := http.NewRequest("GET", "/reports/matrix?weapons="+weapon.ID.Hex()+"&armor="+padded.ID.Hex()+","+laminate.ID.Hex()+"&targetBrawn=2", nil)
+	if err != nil {
+		t.Errorf("GetMatrixReport() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := combat.Matrix{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || len(resp.Rows) != 1 || len(resp.Rows[0]) != 2 {
+		t.Fatalf("GetMatrixReport() error:\ngot: %v %+v\nexpected: %v with a 1 by 2 matrix", w.Code, resp, http.StatusOK)
+	}
+	if resp.Rows[0][1].DamagePerHit != 3 || resp.Rows[0][1].DefenseSetback != 1 {
+		t.Errorf("GetMatrixReport() error:\ngot: %+v\nexpected: 3 damage per hit with 1 setback", resp.Rows[0][1])
+	}
+}
+
+func TestGearService_GetMatrixReport_CSV(t *testing.T) {
+	service, weapon, padded, _ := matrixService()
+
+	r, err := http.NewRequest("GET", "/reports/matrix?weapons="+weapon.ID.Hex()+"&armor="+padded.ID.Hex()+"&format=csv", nil)
+	if err != nil {
+		t.Errorf("GetMatrixReport() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv" || err != nil || len(records) != 2 {
+		t.Errorf("GetMatrixReport() error:\ngot: %v %v %v\nexpected: %v with a header and one record", w.Code, records, err, http.StatusOK)
+	}
+}
+
+func TestGearService_GetMatrixReport_Invalid(t *testing.T) {
+	service, _, _, _ := matrixService()
+
+	r, err := http.NewRequest("GET", "/reports/matrix?weapons=abc", nil)
+	if err != nil {
+		t.Errorf("GetMatrixReport() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 3 {
+		t.Errorf("GetMatrixReport() error:\ngot: %v %+v\nexpected: %v with weapons and armor errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetMatrixReport_NegativeBrawn(t *testing.T) {
+	service, weapon, padded, _ := matrixService()
+
+	r, err := http.NewRequest("GET", "/reports/matrix?weapons="+weapon.ID.Hex()+"&armor="+padded.ID.Hex()+"&brawn=-1&targetBrawn=-2", nil)
+	if err != nil {
+		t.Errorf("GetMatrixReport() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 2 || resp.Fields[0].Field != "brawn" || resp.Fields[1].Field != "targetBrawn" {
+		t.Errorf("GetMatrixReport() error:\ngot: %v %+v\nexpected: %v with brawn and targetBrawn errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetMatrixReport_NotFound(t *testing.T) {
+	service, weapon, _, _ := matrixService()
+
+	r, err := http.NewRequest("GET", "/reports/matrix?weapons="+weapon.ID.Hex()+"&armor="+primitive.NewObjectID().Hex(), nil)
+	if err != nil {
+		t.Errorf("GetMatrixReport() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GetMatrixReport() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNotFound)
+	}
+}
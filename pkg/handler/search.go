@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+)
+
+// The search query parameters, the text searched and the comma separated kinds to search
+const (
+	searchTextParam  = "q"
+	searchKindsParam = "kinds"
+)
+
+//Search is the handler function for the full-text search across armor and weapons, results are paged by relevance
+func (s *GearService) Search(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("Search invoked with url: %v", r.URL)
+
+	query := r.URL.Query()
+	search, err := model.ParseSearchQuery(query.Get(searchTextParam), query.Get(searchKindsParam))
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	_, pageNumber, pageCount, err := api.ParsePage(query, s.MaxPageSize)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	if pageNumber > model.MaxSearchResults/pageCount {
+		respondWithError(w, model.ValidationError{{Field: api.PageNumberParam, Message: fmt.Sprintf("only the first %v results can be paged through, refine the search", model.MaxSearchResults)}})
+		return
+	}
+
+	results, total, err := s.Database.Search(search, pageNumber, pageCount)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithPage(w, r.URL, model.NewPage(results, total, pageNumber, pageCount))
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGearService_Search_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Vibroknife", 250)
+	db := &mocks.MockGearDatabase{
+		SearchResultsToReturn: []model.SearchResult{
+			{Kind: model.WeaponKind, ItemID: weapon.ID, Name: weapon.Name, Score: 1.5, Weapon: &weapon},
+		},
+		CountToReturn: 12,
+	}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/search?q=vibro&kinds=weapon&pageCount=1", nil)
+	if err != nil {
+		t.Errorf("Search() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Search() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+	if db.Searched == nil || db.Searched.Text != "vibro" || db.Searched.Includes(model.ArmorKind) {
+		t.Errorf("Search() error:\ngot: %+v\nexpected: vibro across weapons only", db.Searched)
+	}
+
+	page := struct {
+		Items   []model.SearchResult `json:"items"`
+		Total   int64                `json:"total"`
+		HasNext bool                 `json:"hasNext"`
+	}{}
+	err = json.NewDecoder(w.Body).Decode(&page)
+	if err != nil || len(page.Items) != 1 || page.Items[0].Kind != model.WeaponKind || page.Total != 12 || !page.HasNext {
+		t.Errorf("Search() error:\ngot: %+v, %v\nexpected: the weapon on the first of 12 pages", page, err)
+	}
+	if w.Header().Get("Link") == "" {
+		t.Errorf("Search() error:\ngot: no Link header\nexpected: the page links")
+	}
+}
+
+func TestGearService_Search_Invalid(t *testing.T) {
+	db := &mocks.MockGearDatabase{}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/search?kinds=vehicle", nil)
+	if err != nil {
+		t.Errorf("Search() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest || db.Searched != nil {
+		t.Errorf("Search() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_Search_TooDeep(t *testing.T) {
+	db := &mocks.MockGearDatabase{}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/search?q=blaster&pageCount=100&pageNumber=9223372036854775807", nil)
+	if err != nil {
+		t.Errorf("Search() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest || db.Searched != nil {
+		t.Errorf("Search() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+}
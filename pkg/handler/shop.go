@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/geeksheik9/gear-CRUD/pkg/shops"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//GenerateShop is the handler function to generate and save the stock of a new shop
+func (s *GearService) GenerateShop(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GenerateShop invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	request := model.ShopRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	s.generateShop(w, request, primitive.NewObjectID())
+}
+
+//RegenerateShop is the handler function to replace the stock of a shop, settings missing from the body are kept
+func (s *GearService) RegenerateShop(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("RegenerateShop invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	shop, err := s.Database.GetShopByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	request := model.ShopRequest{
+		Name:           shop.Name,
+		Location:       shop.Location,
+		Specialty:      shop.Specialty,
+		Size:           shop.Size,
+		PlanetModifier: shop.PlanetModifier,
+	}
+	if shop.Location == "" {
+		request.Location = strconv.FormatInt(shop.LocationModifier, 10)
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && err != io.EOF {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	s.generateShop(w, request, objectID)
+}
+
+//GetShopByID is the handler function to return a specific generated shop in the database
+func (s *GearService) GetShopByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetShopByID invoked with url: %v", r.URL)
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	shop, err := s.Database.GetShopByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, shop)
+}
+
+//DeleteShopByID is the handler function to remove a specific generated shop in the database
+func (s *GearService) DeleteShopByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("DeleteShopByID invoked with url: %v", r.URL)
+
+	objectID, err := primitive.ObjectIDFromHex(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.DeleteShopByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+// shopCatalogPageSize is how many weapons or armor are read at a time while gathering a shop's candidates
+const shopCatalogPageSize = 500
+
+// generateShop samples the shop's stock from the whole weapon and armor catalog and saves it under the given ID
+func (s *GearService) generateShop(w http.ResponseWriter, request model.ShopRequest, shopID primitive.ObjectID) {
+	err := request.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	weapons, armors, err := s.shopCatalog(request)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	seed := time.Now().UnixNano()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+
+	shop, err := shops.Generate(request, weapons, armors, seed)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	shop.ID = shopID
+	shop.GeneratedAt = time.Now().UTC()
+
+	err = s.Database.SaveShop(shop)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, shop)
+}
+
+// shopCatalog reads every weapon and armor the shop could stock, following the keyset cursor page by page so no part of
+// the catalog is cut off. Only black markets stock restricted items, the other shops leave them out of the query.
+func (s *GearService) shopCatalog(request model.ShopRequest) ([]model.Weapon, []model.Armor, error) {
+	query := url.Values{api.PageCountParam: {strconv.Itoa(shopCatalogPageSize)}}
+	if request.Specialty != model.BlackMarketStore {
+		query.Set("legal", "true")
+	}
+
+	weapons := []model.Weapon{}
+	err := eachPage(query, func(page url.Values) (string, error) {
+		found, next, err := s.Database.GetWeapon(page)
+		weapons = append(weapons, found...)
+		return next, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	armors := []model.Armor{}
+	err = eachPage(query, func(page url.Values) (string, error) {
+		found, next, err := s.Database.GetArmor(page)
+		armors = append(armors, found...)
+		return next, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return weapons, armors, nil
+}
+
+// eachPage reads the query one page at a time, read returns the cursor of the next page and an empty cursor after the last
+func eachPage(query url.Values, read func(page url.Values) (string, error)) error {
+	page := url.Values{}
+	for key, values := range query {
+		page[key] = values
+	}
+
+	for {
+		next, err := read(page)
+		if err != nil || next == "" {
+			return err
+		}
+		page.Set(api.CursorParam, next)
+	}
+}
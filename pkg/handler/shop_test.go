@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func shopDatabase() *mocks.MockGearDatabase {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	weapon.Rarity = 4
+	armor := mockSingleArmor(primitive.NewObjectID(), "Padded Armor", 500)
+	armor.Rarity = 1
+	return &mocks.MockGearDatabase{WeaponsToReturn: mockWeapons(weapon), ArmorsToReturn: mockArmor(armor)}
+}
+
+func TestGearService_GenerateShop_Success(t *testing.T) {
+	db := shopDatabase()
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"name": "Mos Eisley Outfitters", "location": "Outer Rim", "specialty": "armory", "size": 5, "seed": 3}`
+	r, err := http.NewRequest("POST", "/shops/generate", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("GenerateShop() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.Shop{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || len(resp.Items) != 2 || resp.Seed != 3 || resp.LocationModifier != 1 {
+		t.Errorf("GenerateShop() error:\ngot: %v %+v\nexpected: %v with both catalog items", w.Code, resp, http.StatusOK)
+	}
+	if db.SavedShop == nil || db.SavedShop.ID != resp.ID || resp.ID.IsZero() {
+		t.Errorf("GenerateShop() error:\ngot: %+v\nexpected the shop to be saved", db.SavedShop)
+	}
+}
+
+func TestGearService_GenerateShop_PagesThroughCatalog(t *testing.T) {
+	db := shopDatabase()
+	first := mockWeapon(primitive.NewObjectID(), "Blaster Pistol", 400)
+	last := mockWeapon(primitive.NewObjectID(), "Heavy Blaster Pistol", 700)
+	db.WeaponPages = [][]model.Weapon{{first}, {}, {last}}
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"name": "Mos Eisley Outfitters", "specialty": "armory", "size": 10, "seed": 3}`
+	r, err := http.NewRequest("POST", "/shops/generate", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("GenerateShop() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.Shop{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || len(resp.Items) != 3 {
+		t.Errorf("GenerateShop() error:\ngot: %v %+v\nexpected: %v with the items of every page", w.Code, resp, http.StatusOK)
+	}
+	if db.LastQuery.Get("legal") != "true" || db.LastQuery.Get("pageCount") != "500" {
+		t.Errorf("GenerateShop() error:\ngot: %v\nexpected: pages of 500 legal items", db.LastQuery)
+	}
+}
+
+func TestGearService_GenerateShop_Invalid(t *testing.T) {
+	service := GearService{Version: "test", Database: shopDatabase()}
+
+	r, err := http.NewRequest("POST", "/shops/generate", bytes.NewBufferString(`{"specialty": "cantina", "size": 500}`))
+	if err != nil {
+		t.Errorf("GenerateShop() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 2 {
+		t.Errorf("GenerateShop() error:\ngot: %v %+v\nexpected: %v with specialty and size errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_RegenerateShop_KeepsSettings(t *testing.T) {
+	db := shopDatabase()
+	existing := model.Shop{ID: primitive.NewObjectID(), Name: "Docking Bay 94", Location: "Core Worlds", LocationModifier: -1, Specialty: model.GeneralStore, Size: 1, Seed: 1}
+	db.ShopToReturn = &existing
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("POST", "/shops/"+existing.ID.Hex()+"/regenerate", bytes.NewBufferString(`{"seed": 8}`))
+	if err != nil {
+		t.Errorf("RegenerateShop() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	saved := db.SavedShop
+	if w.Code != http.StatusOK || saved == nil || saved.ID != existing.ID || saved.Name != "Docking Bay 94" || saved.Seed != 8 || len(saved.Items) != 1 || saved.Specialty != model.GeneralStore {
+		t.Errorf("RegenerateShop() error:\ngot: %v %+v\nexpected: %v with the stored settings and new seed", w.Code, saved, http.StatusOK)
+	}
+}
+
+func TestGearService_GetShopByID_NotFound(t *testing.T) {
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{ErrorToReturn: mongo.ErrNoDocuments}}
+
+	r, err := http.NewRequest("GET", "/shops/"+primitive.NewObjectID().Hex(), nil)
+	if err != nil {
+		t.Errorf("GetShopByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GetShopByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/geeksheik9/gear-CRUD/pkg/combat"
+	"github.com/sirupsen/logrus"
+)
+
+//SimulateAttacks is the handler function to simulate attacks with a weapon against a target wearing armor
+func (s *GearService) SimulateAttacks(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("SimulateAttacks invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	request := combat.SimulationRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = request.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(request.WeaponID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	var armor *model.Armor
+	if !request.ArmorID.IsZero() {
+		armor, err = s.Database.GetArmorByID(request.ArmorID)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), err.Error())
+			return
+		}
+	}
+
+	seed := time.Now().UnixNano()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), request.TimeBudget())
+	defer cancel()
+
+	result, err := combat.Simulate(ctx, weapon, armor, request, seed)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	if result.Truncated {
+		logrus.Warnf("SimulateAttacks completed %v of %v runs within the time budget", result.CompletedRuns, result.Runs)
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, result)
+}
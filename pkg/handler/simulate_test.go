@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/combat"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGearService_SimulateAttacks_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Blaster Rifle", 900)
+	weapon.Skill = "Ranged (Heavy)"
+	weapon.Damage = model.Damage{Base: 9}
+	weapon.Critical = 3
+	armor := mockSingleArmor(primitive.NewObjectID(), "Padded Armor", 500)
+	armor.Soak = 2
+	service := InitMockGearService(&armor, nil, &weapon, nil, nil)
+
+	body := `{"weaponId": "` + weapon.ID.Hex() + `", "armorId": "` + armor.ID.Hex() + `", "characteristics": {"agility": 3},
+		"skills": {"Ranged (Heavy)": 1}, "targetBrawn": 2, "runs": 2000, "seed": 5}`
+	r, err := http.NewRequest("POST", "/simulate", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("SimulateAttacks() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := combat.SimulationResult{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil {
+		t.Errorf("SimulateAttacks() error:\ngot: %v %v\nexpected: %v", w.Code, err, http.StatusOK)
+	}
+	if resp.Seed != 5 || resp.CompletedRuns != 2000 || resp.Soak != 4 || resp.Armor != "Padded Armor" || resp.ExpectedDamage <= 0 {
+		t.Errorf("SimulateAttacks() error:\ngot: %+v\nexpected: 2000 seeded runs against soak 4", resp)
+	}
+}
+
+func TestGearService_SimulateAttacks_Invalid(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	r, err := http.NewRequest("POST", "/simulate", bytes.NewBufferString(`{"runs": -5}`))
+	if err != nil {
+		t.Errorf("SimulateAttacks() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 2 {
+		t.Errorf("SimulateAttacks() error:\ngot: %v %+v\nexpected: %v with weaponId and runs errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/sirupsen/logrus"
+)
+
+//GetSources is the handler function to list every source book with the number of items printed in it
+func (s *GearService) GetSources(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetSources invoked with url: %v", r.URL)
+
+	counts, err := s.Database.GetSourceCounts()
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, counts)
+}
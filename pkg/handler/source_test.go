@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+)
+
+func TestGearService_GetSources_Success(t *testing.T) {
+	db := &mocks.MockGearDatabase{SourceCountsToReturn: []model.SourceCount{
+		{SourceBook: model.SourceBook{Code: "EotE-CRB", GameLine: model.EdgeOfTheEmpire}, Weapons: 3, Armor: 2, Total: 5},
+	}}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/sources", nil)
+	if err != nil {
+		t.Errorf("GetSources() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := []model.SourceCount{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || len(resp) != 1 || resp[0].Code != "EotE-CRB" || resp[0].Total != 5 {
+		t.Errorf("GetSources() error:\ngot: %v %+v\nexpected: %v with EotE-CRB counts", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_GetSources_Error(t *testing.T) {
+	service := InitMockGearService(nil, nil, nil, nil, errors.New("E1"))
+
+	r, err := http.NewRequest("GET", "/sources", nil)
+	if err != nil {
+		t.Errorf("GetSources() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("GetSources() error:\ngot: %v\nexpected: %v", w.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//InsertArmorV2 is the v2 handler function for inserting an armor object
+func (s *GearService) InsertArmorV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertArmorV2 invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	request := model.ArmorV2{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	armor := model.FromArmorV2(request)
+	armor.ID = primitive.NewObjectID()
+
+	err = armor.Validate()
+	if err != nil {
+		respondWithErrorV2(w, err, model.ArmorV2Fields)
+		return
+	}
+
+	err = s.Database.InsertArmor(&armor)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusCreated, model.ToArmorV2(armor))
+}
+
+//InsertWeaponV2 is the v2 handler function for inserting a weapon object
+func (s *GearService) InsertWeaponV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertWeaponV2 invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	request := model.WeaponV2{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	weapon := model.FromWeaponV2(request)
+	weapon.ID = primitive.NewObjectID()
+
+	err = weapon.Validate()
+	if err != nil {
+		respondWithErrorV2(w, err, model.WeaponV2Fields)
+		return
+	}
+
+	err = s.Database.InsertWeapon(&weapon)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusCreated, model.ToWeaponV2(weapon))
+}
+
+//GetArmorV2 is the v2 handler function to return all armor in the database, filters and sorts use the v2 field names
+func (s *GearService) GetArmorV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetArmorV2 invoked with url: %v", r.URL)
+
+	query, pageNumber, pageCount, err := api.ParsePage(translateQueryV2(r.URL.Query(), model.ArmorV2Fields), s.MaxPageSize)
+	if err != nil {
+		respondWithErrorV2(w, err, model.ArmorV2Fields)
+		return
+	}
+
+	armor, nextCursor, err := s.Database.GetArmor(query)
+	if err != nil {
+		respondWithErrorV2(w, err, model.ArmorV2Fields)
+		return
+	}
+
+	total, err := s.Database.CountArmor(query)
+	if err != nil {
+		respondWithErrorV2(w, err, model.ArmorV2Fields)
+		return
+	}
+
+	response := make([]model.ArmorV2, 0, len(armor))
+	for _, a := range armor {
+		response = append(response, model.ToArmorV2(a))
+	}
+
+	page := model.NewPage(response, total, pageNumber, pageCount).WithCursor(nextCursor, query.Get(api.CursorParam) != "")
+	api.RespondWithPage(w, r.URL, page)
+}
+
+//GetWeaponV2 is the v2 handler function to return all weapons in the database, filters and sorts use the v2 field names
+func (s *GearService) GetWeaponV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetWeaponV2 invoked with url: %v", r.URL)
+
+	query, pageNumber, pageCount, err := api.ParsePage(translateQueryV2(r.URL.Query(), model.WeaponV2Fields), s.MaxPageSize)
+	if err != nil {
+		respondWithErrorV2(w, err, model.WeaponV2Fields)
+		return
+	}
+
+	weapons, nextCursor, err := s.Database.GetWeapon(query)
+	if err != nil {
+		respondWithErrorV2(w, err, model.WeaponV2Fields)
+		return
+	}
+
+	total, err := s.Database.CountWeapon(query)
+	if err != nil {
+		respondWithErrorV2(w, err, model.WeaponV2Fields)
+		return
+	}
+
+	response := make([]model.WeaponV2, 0, len(weapons))
+	for _, weapon := range weapons {
+		response = append(response, model.ToWeaponV2(weapon))
+	}
+
+	page := model.NewPage(response, total, pageNumber, pageCount).WithCursor(nextCursor, query.Get(api.CursorParam) != "")
+	api.RespondWithPage(w, r.URL, page)
+}
+
+//GetArmorByIDV2 is the v2 handler function to return a specific armor in the database
+func (s *GearService) GetArmorByIDV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetArmorByIDV2 invoked with url: %v", r.URL)
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	armor, err := s.Database.GetArmorByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, model.ToArmorV2(*armor))
+}
+
+//GetWeaponByIDV2 is the v2 handler function to return a specific weapon in the database
+func (s *GearService) GetWeaponByIDV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetWeaponByIDV2 invoked with url: %v", r.URL)
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	weapon, err := s.Database.GetWeaponByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, model.ToWeaponV2(*weapon))
+}
+
+//UpdateArmorByIDV2 is the v2 handler function to update a specific armor in the database
+func (s *GearService) UpdateArmorByIDV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateArmorByIDV2 invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	request := model.ArmorV2{}
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	armor := model.FromArmorV2(request)
+	armor.ID = objectID
+
+	err = armor.Validate()
+	if err != nil {
+		respondWithErrorV2(w, err, model.ArmorV2Fields)
+		return
+	}
+
+	err = s.Database.UpdateArmorByID(armor, objectID)
+	if err != nil {
+		respondWithErrorV2(w, err, model.ArmorV2Fields)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, model.ToArmorV2(armor))
+}
+
+//UpdateWeaponByIDV2 is the v2 handler function to update a specific weapon in the database
+func (s *GearService) UpdateWeaponByIDV2(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateWeaponByIDV2 invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	objectID, err := api.StringToObjectID(mux.Vars(r)["ID"])
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	request := model.WeaponV2{}
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	weapon := model.FromWeaponV2(request)
+	weapon.ID = objectID
+
+	err = weapon.Validate()
+	if err != nil {
+		respondWithErrorV2(w, err, model.WeaponV2Fields)
+		return
+	}
+
+	err = s.Database.UpdateWeaponByID(weapon, objectID)
+	if err != nil {
+		respondWithErrorV2(w, err, model.WeaponV2Fields)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, model.ToWeaponV2(weapon))
+}
+
+// translateQueryV2 renames the v2 filter and sort fields in the query to the names used by the stored documents, keeping any
+// operator and sort direction
+func translateQueryV2(query url.Values, fields map[string]string) url.Values {
+	translated := url.Values{}
+	for param, values := range query {
+		field, operator := api.SplitOperator(param)
+		if stored, ok := fields[field]; ok {
+			param = stored
+			if operator != "" {
+				param += "[" + operator + "]"
+			}
+		}
+		if param == api.SortParam {
+			sorted := make([]string, 0, len(values))
+			for _, value := range values {
+				keys := strings.Split(value, ",")
+				for i, key := range keys {
+					key = strings.TrimSpace(key)
+					descending := strings.HasPrefix(key, "-")
+					if stored, ok := fields[strings.TrimPrefix(key, "-")]; ok {
+						key = stored
+						if descending {
+							key = "-" + key
+						}
+					}
+					keys[i] = key
+				}
+				sorted = append(sorted, strings.Join(keys, ","))
+			}
+			values = sorted
+		}
+		translated[param] = values
+	}
+	return translated
+}
+
+// respondWithErrorV2 responds like respondWithError with the fields named in the errors renamed to their v2 names, both the
+// invalid field and the field names the filter and sort messages refer to
+func respondWithErrorV2(w http.ResponseWriter, err error, fields map[string]string) {
+	var validationErr model.ValidationError
+	if !errors.As(err, &validationErr) {
+		respondWithError(w, err)
+		return
+	}
+
+	names := model.V2FieldNames(fields)
+	renamed := model.ValidationError{}
+	for _, fieldErr := range validationErr {
+		words := strings.Split(fieldErr.Message, " ")
+		for i, word := range words {
+			if v2, ok := names[word]; ok {
+				words[i] = v2
+			}
+		}
+		renamed.Add(renameFieldV2(fieldErr.Field, names), strings.Join(words, " "))
+	}
+	respondWithError(w, renamed)
+}
+
+// renameFieldV2 renames the root of a field path such as hp, special[0].name or encumberence[gt] to its v2 name
+func renameFieldV2(field string, names map[string]string) string {
+	root := field
+	if end := strings.IndexAny(field, ".["); end >= 0 {
+		root = field[:end]
+	}
+	if v2, ok := names[root]; ok {
+		return v2 + strings.TrimPrefix(field, root)
+	}
+	return field
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGearService_InsertArmorV2_Success(t *testing.T) {
+	db := &mocks.MockGearDatabase{}
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"name": "Padded Armor", "soak": 2, "encumbrance": 2, "rarity": 1, "hardPoints": 2}`
+	r, err := http.NewRequest("POST", "/v2/armor", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("InsertArmorV2() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ArmorV2{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusCreated || err != nil || resp.Name != "Padded Armor" || resp.ID.IsZero() {
+		t.Errorf("InsertArmorV2() error:\ngot: %v %+v\nexpected: %v", w.Code, resp, http.StatusCreated)
+	}
+	if db.InsertedArmor == nil || db.InsertedArmor.ArmorType != "Padded Armor" || db.InsertedArmor.Encumbrance != 2 {
+		t.Errorf("InsertArmorV2() error:\ngot: %+v\nexpected the armor stored with its v1 type", db.InsertedArmor)
+	}
+}
+
+func TestGearService_InsertWeaponV2_InvalidQuality(t *testing.T) {
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{}}
+
+	body := `{"name": "DL-44", "damage": {"base": 7}, "qualities": [{"name": "Sparkly"}]}`
+	r, err := http.NewRequest("POST", "/v2/weapon", bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("InsertWeaponV2() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "qualities[0]" {
+		t.Errorf("InsertWeaponV2() error:\ngot: %v %+v\nexpected: %v with a qualities error", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_UpdateWeaponByIDV2_HardPointsInUse(t *testing.T) {
+	inUse := model.ValidationError{{Field: "hp", Message: "installed attachments use more than 0 hard points, remove attachments first"}}
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{ErrorToReturn: inUse}}
+
+	body := `{"name": "DL-44", "damage": {"base": 7}, "hardPoints": 0}`
+	r, err := http.NewRequest("PUT", "/v2/weapon/"+primitive.NewObjectID().Hex(), bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("UpdateWeaponByIDV2() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "hardPoints" {
+		t.Errorf("UpdateWeaponByIDV2() error:\ngot: %v %+v\nexpected: %v with a hardPoints error", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_UpdateArmorByIDV2_ClearQualities(t *testing.T) {
+	db := &mocks.MockGearDatabase{}
+	service := GearService{Version: "test", Database: db}
+
+	body := `{"name": "Padded Armor", "soak": 2, "qualities": []}`
+	r, err := http.NewRequest("PUT", "/v2/armor/"+primitive.NewObjectID().Hex(), bytes.NewBufferString(body))
+	if err != nil {
+		t.Errorf("UpdateArmorByIDV2() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || db.UpdatedArmor == nil {
+		t.Fatalf("UpdateArmorByIDV2() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+
+	document, _ := bson.Marshal(db.UpdatedArmor)
+	qualities, ok := bson.Raw(document).Lookup("qualities").ArrayOK()
+	if values, _ := qualities.Values(); !ok || len(values) != 0 {
+		t.Errorf("UpdateArmorByIDV2() error:\ngot: %v\nexpected: an empty qualities array", document)
+	}
+}
+
+func TestGearService_GetWeaponByIDV2_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "DL-44", 750)
+	weapon.Encumberence = 1
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{WeaponToReturn: &weapon}}
+
+	r, err := http.NewRequest("GET", "/v2/weapon/"+weapon.ID.Hex(), nil)
+	if err != nil {
+		t.Errorf("GetWeaponByIDV2() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := map[string]interface{}{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusOK || err != nil || resp["encumbrance"] != float64(1) || resp["id"] != weapon.ID.Hex() || resp["encumberence"] != nil {
+		t.Errorf("GetWeaponByIDV2() error:\ngot: %v %v\nexpected: %v with the v2 fields", w.Code, resp, http.StatusOK)
+	}
+}
+
+func TestGearService_GetWeaponV2_InvalidFilterAndSort(t *testing.T) {
+	// the database validates the translated query, its errors name the stored fields
+	invalid := model.ValidationError{
+		{Field: "_id[regex]", Message: "_id is not a text field"},
+		{Field: "sort", Message: "encumberence is sorted on more than once"},
+	}
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{ErrorToReturn: invalid}}
+
+	r, err := http.NewRequest("GET", "/v2/weapon?id[regex]=^5f&sort=encumbrance,-encumbrance", nil)
+	if err != nil {
+		t.Errorf("GetWeaponV2() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	expected := []model.FieldError{
+		{Field: "id[regex]", Message: "id is not a text field"},
+		{Field: "sort", Message: "encumbrance is sorted on more than once"},
+	}
+	if w.Code != http.StatusBadRequest || err != nil || !reflect.DeepEqual(resp.Fields, expected) {
+		t.Errorf("GetWeaponV2() error:\ngot: %v %+v\nexpected: %v %+v", w.Code, resp.Fields, http.StatusBadRequest, expected)
+	}
+}
+
+func TestGearService_GetArmorV2_InvalidFilter(t *testing.T) {
+	invalid := model.ValidationError{{Field: "type[gt]", Message: "unsupported operator gt"}}
+	service := GearService{Version: "test", Database: &mocks.MockGearDatabase{ErrorToReturn: invalid}}
+
+	r, err := http.NewRequest("GET", "/v2/armor?name[gt]=Padded", nil)
+	if err != nil {
+		t.Errorf("GetArmorV2() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "name[gt]" {
+		t.Errorf("GetArmorV2() error:\ngot: %v %+v\nexpected: %v with a name[gt] error", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestTranslateQueryV2(t *testing.T) {
+	query := url.Values{"name[regex]": {"^Padded"}, "sort": {"-name,soak"}, "soak": {"2"}}
+
+	translated := translateQueryV2(query, model.ArmorV2Fields)
+	expected := url.Values{"type[regex]": {"^Padded"}, "sort": {"-type,soak"}, "soak": {"2"}}
+	if !reflect.DeepEqual(translated, expected) {
+		t.Errorf("translateQueryV2() error:\ngot: %v\nexpected: %v", translated, expected)
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//InsertVehicle is the handler function for inserting a vehicle object
+func (s *GearService) InsertVehicle(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("InsertVehicle invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	var vehicleModel model.Vehicle
+	vehicleModel.ID = primitive.NewObjectID()
+
+	err := json.NewDecoder(r.Body).Decode(&vehicleModel)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Invalid Request Payload")
+		return
+	}
+
+	err = vehicleModel.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.validateVehicleWeapons(vehicleModel.Weapons)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.InsertVehicle(&vehicleModel)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, "Vehicle Object Created")
+}
+
+//GetVehicle is the handler function to return all vehicles in the database
+func (s *GearService) GetVehicle(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetVehicle invoked with url: %v", r.URL)
+
+	vehicles, err := s.Database.GetVehicle(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, vehicles)
+}
+
+//GetVehicleByID is the handler function to return a specific vehicle in the database
+func (s *GearService) GetVehicleByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("GetVehicleByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := api.StringToObjectID(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	vehicle, err := s.Database.GetVehicleByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("expand") == "weapons" {
+		err = s.expandVehicleWeapons(vehicle)
+		if err != nil {
+			api.RespondWithError(w, api.CheckError(err), err.Error())
+			return
+		}
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, vehicle)
+}
+
+//UpdateVehicleByID is the handler function to update a specific vehicle in the database
+func (s *GearService) UpdateVehicleByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("UpdateVehicleByID invoked with url: %v", r.URL)
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	vehicle := model.Vehicle{}
+	err = json.NewDecoder(r.Body).Decode(&vehicle)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = vehicle.Validate()
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.validateVehicleWeapons(vehicle.Weapons)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = s.Database.UpdateVehicleByID(vehicle, objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, objectID)
+}
+
+//DeleteVehicleByID is the handler function to remove a specific vehicle in the database
+func (s *GearService) DeleteVehicleByID(w http.ResponseWriter, r *http.Request) {
+	logrus.Infof("DeleteVehicleByID invoked with url: %v", r.URL)
+
+	vars := mux.Vars(r)
+	ID := vars["ID"]
+
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	err = s.Database.DeleteVehicleByID(objectID)
+	if err != nil {
+		api.RespondWithError(w, api.CheckError(err), err.Error())
+		return
+	}
+
+	api.RespondNoContent(w, http.StatusNoContent)
+}
+
+// validateVehicleWeapons checks that every mounted weapon references a weapon in the catalog
+func (s *GearService) validateVehicleWeapons(weapons []model.VehicleWeapon) error {
+	validationErr := model.ValidationError{}
+
+	for i, mounted := range weapons {
+		_, err := s.Database.GetWeaponByID(mounted.WeaponID)
+		if err == nil {
+			continue
+		}
+		if api.CheckError(err) != http.StatusNotFound {
+			return err
+		}
+		validationErr.Add(fmt.Sprintf("weapons[%d].weaponId", i), fmt.Sprintf("weapon %v does not exist", mounted.WeaponID.Hex()))
+	}
+
+	return validationErr.OrNil()
+}
+
+// expandVehicleWeapons inlines the referenced catalog weapons, weapons removed from the catalog are left unexpanded
+func (s *GearService) expandVehicleWeapons(vehicle *model.Vehicle) error {
+	for i, mounted := range vehicle.Weapons {
+		weapon, err := s.Database.GetWeaponByID(mounted.WeaponID)
+		if err != nil {
+			if api.CheckError(err) == http.StatusNotFound {
+				logrus.Warnf("Vehicle %v references missing weapon %v", vehicle.ID.Hex(), mounted.WeaponID.Hex())
+				continue
+			}
+			return err
+		}
+		vehicle.Weapons[i].Weapon = weapon
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/db/mocks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mockVehicle(id primitive.ObjectID, name string, weapons ...model.VehicleWeapon) model.Vehicle {
+	return model.Vehicle{
+		ID:          id,
+		VehicleType: "Starfighter",
+		Name:        name,
+		Silhouette:  3,
+		Speed:       5,
+		Handling:    1,
+		Defense:     model.DefenseArcs{Fore: 1, Aft: 1},
+		Weapons:     weapons,
+	}
+}
+
+func TestGearService_InsertVehicle_Success(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Light Laser Cannon", 0)
+	vehicle := mockVehicle(primitive.NewObjectID(), "T-65B X-wing", model.VehicleWeapon{WeaponID: weapon.ID, FireArc: "fore", Count: 4})
+	db := &mocks.MockGearDatabase{WeaponByID: map[primitive.ObjectID]*model.Weapon{weapon.ID: &weapon}}
+	service := GearService{Version: "test", Database: db}
+
+	request, _ := json.Marshal(vehicle)
+
+	r, err := http.NewRequest("POST", "/vehicle", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("InsertVehicle() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("InsertVehicle() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestGearService_InsertVehicle_MissingWeapon(t *testing.T) {
+	vehicle := mockVehicle(primitive.NewObjectID(), "T-65B X-wing", model.VehicleWeapon{WeaponID: primitive.NewObjectID(), FireArc: model.ForeArc, Count: 4})
+	db := &mocks.MockGearDatabase{WeaponByID: map[primitive.ObjectID]*model.Weapon{}}
+	service := GearService{Version: "test", Database: db}
+
+	request, _ := json.Marshal(vehicle)
+
+	r, err := http.NewRequest("POST", "/vehicle", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("InsertVehicle() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InsertVehicle() error:\ngot: %v\nexpected: %v", w.Code, http.StatusBadRequest)
+	}
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || len(resp.Fields) != 1 || resp.Fields[0].Field != "weapons[0].weaponId" {
+		t.Errorf("InsertVehicle() error:\ngot: %v\nexpected: weaponId field error", resp)
+	}
+}
+
+func TestGearService_InsertVehicle_Invalid(t *testing.T) {
+	vehicle := mockVehicle(primitive.NewObjectID(), "Broken Speeder", model.VehicleWeapon{WeaponID: primitive.NewObjectID(), FireArc: "Dorsal"})
+	vehicle.Silhouette = 11
+	service := InitMockGearService(nil, nil, nil, nil, nil)
+
+	request, _ := json.Marshal(vehicle)
+
+	r, err := http.NewRequest("POST", "/vehicle", bytes.NewBuffer(request))
+	if err != nil {
+		t.Errorf("InsertVehicle() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := model.ValidationErrorResponse{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if w.Code != http.StatusBadRequest || err != nil || len(resp.Fields) != 3 {
+		t.Errorf("InsertVehicle() error:\ngot: %v %v\nexpected: %v with silhouette, count and fireArc errors", w.Code, resp, http.StatusBadRequest)
+	}
+}
+
+func TestGearService_GetVehicleByID_ExpandWeapons(t *testing.T) {
+	weapon := mockWeapon(primitive.NewObjectID(), "Light Laser Cannon", 0)
+	missing := primitive.NewObjectID()
+	vehicle := mockVehicle(primitive.NewObjectID(), "T-65B X-wing",
+		model.VehicleWeapon{WeaponID: weapon.ID, FireArc: model.ForeArc, Count: 4},
+		model.VehicleWeapon{WeaponID: missing, FireArc: model.ForeArc, Count: 1},
+	)
+	db := &mocks.MockGearDatabase{
+		VehicleToReturn: &vehicle,
+		WeaponByID:      map[primitive.ObjectID]*model.Weapon{weapon.ID: &weapon},
+	}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/vehicle/"+vehicle.ID.Hex()+"?expand=weapons", nil)
+	if err != nil {
+		t.Errorf("GetVehicleByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("GetVehicleByID() error:\ngot: %v\nexpected: %v", w.Code, http.StatusOK)
+	}
+
+	resp := model.Vehicle{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || len(resp.Weapons) != 2 || resp.Weapons[0].Weapon == nil || resp.Weapons[0].Weapon.Name != weapon.Name || resp.Weapons[1].Weapon != nil {
+		t.Errorf("GetVehicleByID() error:\ngot: %+v\nexpected: first weapon expanded", resp.Weapons)
+	}
+}
+
+func TestGearService_GetVehicleByID_NoExpand(t *testing.T) {
+	vehicle := mockVehicle(primitive.NewObjectID(), "T-65B X-wing", model.VehicleWeapon{WeaponID: primitive.NewObjectID(), FireArc: model.ForeArc, Count: 4})
+	db := &mocks.MockGearDatabase{VehicleToReturn: &vehicle}
+	service := GearService{Version: "test", Database: db}
+
+	r, err := http.NewRequest("GET", "/vehicle/"+vehicle.ID.Hex(), nil)
+	if err != nil {
+		t.Errorf("GetVehicleByID() error creating request:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	w := httptest.NewRecorder()
+	router := mux.NewRouter().StrictSlash(true)
+	service.Routes(router).ServeHTTP(w, r)
+
+	resp := map[string]interface{}{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	weapons, _ := resp["weapons"].([]interface{})
+	if err != nil || len(weapons) != 1 || weapons[0].(map[string]interface{})["weapon"] != nil {
+		t.Errorf("GetVehicleByID() error:\ngot: %v\nexpected: unexpanded weapon reference", resp["weapons"])
+	}
+}
@@ -0,0 +1,210 @@
+// Package loot rolls on loot tables, resolving nested tables down to concrete weapons and armor.
+// A roll only depends on the tables, the catalog, the roll request and the seed so the same inputs always drop the same loot.
+package loot
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Source looks up the tables and catalog items referenced by loot entries
+type Source interface {
+	GetLootTableByID(mongoID primitive.ObjectID) (*model.LootTable, error)
+	GetArmorByID(mongoID primitive.ObjectID) (*model.Armor, error)
+	GetWeaponByID(mongoID primitive.ObjectID) (*model.Weapon, error)
+}
+
+// roller holds the state of a single roll, tables and items are cached so a document is only read once per roll
+type roller struct {
+	ctx     context.Context
+	source  Source
+	request model.LootRollRequest
+	random  *rand.Rand
+	tables  map[primitive.ObjectID]*model.LootTable
+	weapons map[primitive.ObjectID]*model.Weapon
+	armors  map[primitive.ObjectID]*model.Armor
+	drops   []model.LootDrop
+	draws   int
+}
+
+// Roll draws from the table, rolling again on nested tables, and returns the combined drops.
+// Entries whose conditions do not match the request are never drawn, a table without eligible entries drops nothing.
+// A roll needing more than MaxLootDraws draws across its nested tables fails, as does a roll outliving the context.
+func Roll(ctx context.Context, source Source, table *model.LootTable, request model.LootRollRequest, seed int64) (model.LootRoll, error) {
+	r := roller{
+		ctx:     ctx,
+		source:  source,
+		request: request,
+		random:  rand.New(rand.NewSource(seed)),
+		tables:  map[primitive.ObjectID]*model.LootTable{table.ID: table},
+		weapons: map[primitive.ObjectID]*model.Weapon{},
+		armors:  map[primitive.ObjectID]*model.Armor{},
+		drops:   []model.LootDrop{},
+	}
+
+	err := r.roll(table, []primitive.ObjectID{table.ID})
+	if err != nil {
+		return model.LootRoll{}, err
+	}
+
+	return model.LootRoll{
+		TableID: table.ID,
+		Table:   table.Name,
+		Seed:    seed,
+		Items:   r.drops,
+	}, nil
+}
+
+// roll draws the table's rolls, path holds the tables above this one so reference cycles are caught
+func (r *roller) roll(table *model.LootTable, path []primitive.ObjectID) error {
+	eligible := []model.LootEntry{}
+	for _, entry := range table.Entries {
+		if entry.Conditions.Matches(r.request) {
+			eligible = append(eligible, entry)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil
+	}
+
+	for i := int64(0); i < table.Rolls; i++ {
+		err := r.draw()
+		if err != nil {
+			return err
+		}
+
+		entry := eligible[pick(r.random, eligible)]
+		quantity := entry.MinQuantity
+		if entry.MaxQuantity > entry.MinQuantity {
+			quantity += r.random.Int63n(entry.MaxQuantity - entry.MinQuantity + 1)
+		}
+
+		if entry.TableID == nil {
+			err := r.drop(table, entry, quantity)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		nested, err := r.table(*entry.TableID, path)
+		if err != nil {
+			return err
+		}
+		for n := int64(0); n < quantity; n++ {
+			err = r.roll(nested, append(path, nested.ID))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// draw counts a draw against the roll's budget, failing once the budget is spent or the context is done
+func (r *roller) draw() error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+
+	r.draws++
+	if r.draws > model.MaxLootDraws {
+		validationErr := model.ValidationError{}
+		validationErr.Add("rolls", fmt.Sprintf("loot tables must not need more than %v draws in a single roll", model.MaxLootDraws))
+		return validationErr
+	}
+	return nil
+}
+
+// table returns the nested table, failing when it was already rolled above or the nesting is too deep
+func (r *roller) table(tableID primitive.ObjectID, path []primitive.ObjectID) (*model.LootTable, error) {
+	for _, parent := range path {
+		if parent == tableID {
+			validationErr := model.ValidationError{}
+			validationErr.Add("tableId", fmt.Sprintf("loot table %v references itself through a nested table", tableID.Hex()))
+			return nil, validationErr
+		}
+	}
+	if len(path) >= model.MaxLootNestDepth {
+		validationErr := model.ValidationError{}
+		validationErr.Add("tableId", fmt.Sprintf("loot tables must not be nested more than %v deep", model.MaxLootNestDepth))
+		return nil, validationErr
+	}
+
+	if table, ok := r.tables[tableID]; ok {
+		return table, nil
+	}
+
+	table, err := r.source.GetLootTableByID(tableID)
+	if err != nil {
+		return nil, fmt.Errorf("loot table %v: %v", tableID.Hex(), err)
+	}
+	r.tables[tableID] = table
+	return table, nil
+}
+
+// drop adds the entry's item to the drops, adding to the quantity when the same item dropped earlier
+func (r *roller) drop(table *model.LootTable, entry model.LootEntry, quantity int64) error {
+	itemID := *entry.ItemID
+	for i := range r.drops {
+		if r.drops[i].Kind == entry.Kind && r.drops[i].ItemID == itemID {
+			r.drops[i].Quantity += quantity
+			return nil
+		}
+	}
+
+	drop := model.LootDrop{Kind: entry.Kind, ItemID: itemID, Quantity: quantity, Table: table.Name}
+	switch entry.Kind {
+	case model.WeaponKind:
+		weapon, ok := r.weapons[itemID]
+		if !ok {
+			var err error
+			weapon, err = r.source.GetWeaponByID(itemID)
+			if err != nil {
+				return fmt.Errorf("weapon %v in loot table %v: %v", itemID.Hex(), table.Name, err)
+			}
+			r.weapons[itemID] = weapon
+		}
+		drop.Name = weapon.Name
+		drop.Weapon = weapon
+	case model.ArmorKind:
+		armor, ok := r.armors[itemID]
+		if !ok {
+			var err error
+			armor, err = r.source.GetArmorByID(itemID)
+			if err != nil {
+				return fmt.Errorf("armor %v in loot table %v: %v", itemID.Hex(), table.Name, err)
+			}
+			r.armors[itemID] = armor
+		}
+		drop.Name = armor.ArmorType
+		drop.Armor = armor
+	default:
+		return fmt.Errorf("loot table %v has an entry of unsupported kind %v", table.Name, entry.Kind)
+	}
+
+	r.drops = append(r.drops, drop)
+	return nil
+}
+
+// pick returns the index of a randomly chosen entry, weighted by the entries' weights
+func pick(random *rand.Rand, entries []model.LootEntry) int {
+	var total int64
+	for _, entry := range entries {
+		total += entry.Weight
+	}
+
+	roll := random.Int63n(total)
+	for i, entry := range entries {
+		if roll < entry.Weight {
+			return i
+		}
+		roll -= entry.Weight
+	}
+	return len(entries) - 1
+}
@@ -0,0 +1,161 @@
+package loot
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type source struct {
+	tables  map[primitive.ObjectID]*model.LootTable
+	weapons map[primitive.ObjectID]*model.Weapon
+	armors  map[primitive.ObjectID]*model.Armor
+}
+
+func (s source) GetLootTableByID(mongoID primitive.ObjectID) (*model.LootTable, error) {
+	if table, ok := s.tables[mongoID]; ok {
+		return table, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s source) GetWeaponByID(mongoID primitive.ObjectID) (*model.Weapon, error) {
+	if weapon, ok := s.weapons[mongoID]; ok {
+		return weapon, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s source) GetArmorByID(mongoID primitive.ObjectID) (*model.Armor, error) {
+	if armor, ok := s.armors[mongoID]; ok {
+		return armor, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func objectID() *primitive.ObjectID {
+	id := primitive.NewObjectID()
+	return &id
+}
+
+func fixtures() (source, *model.LootTable) {
+	blaster, vibroknife, armor := objectID(), objectID(), objectID()
+	nested := &model.LootTable{ID: *objectID(), Name: "Officer Sidearms", Rolls: 1, Entries: []model.LootEntry{
+		{Kind: model.WeaponKind, ItemID: vibroknife, Weight: 1, MinQuantity: 1, MaxQuantity: 1},
+	}}
+	table := &model.LootTable{ID: *objectID(), Name: "Imperial Patrol", Rolls: 4, Entries: []model.LootEntry{
+		{Kind: model.WeaponKind, ItemID: blaster, Weight: 5, MinQuantity: 1, MaxQuantity: 3},
+		{Kind: model.ArmorKind, ItemID: armor, Weight: 5, MinQuantity: 1, MaxQuantity: 1,
+			Conditions: model.LootConditions{Adversaries: []model.AdversaryType{model.Minion}}},
+		{TableID: &nested.ID, Weight: 5, MinQuantity: 2, MaxQuantity: 2},
+	}}
+
+	return source{
+		tables:  map[primitive.ObjectID]*model.LootTable{nested.ID: nested, table.ID: table},
+		weapons: map[primitive.ObjectID]*model.Weapon{*blaster: {ID: *blaster, Name: "Blaster Rifle"}, *vibroknife: {ID: *vibroknife, Name: "Vibroknife"}},
+		armors:  map[primitive.ObjectID]*model.Armor{*armor: {ID: *armor, ArmorType: "Stormtrooper Armor"}},
+	}, table
+}
+
+func TestRoll_Deterministic(t *testing.T) {
+	src, table := fixtures()
+
+	first, err := Roll(context.Background(), src, table, model.LootRollRequest{Adversary: model.Minion}, 11)
+	if err != nil {
+		t.Fatalf("Roll() error:\ngot: %v\nexpected: <no error>", err)
+	}
+	second, _ := Roll(context.Background(), src, table, model.LootRollRequest{Adversary: model.Minion}, 11)
+
+	if !reflect.DeepEqual(first, second) || first.Seed != 11 || len(first.Items) == 0 {
+		t.Errorf("Roll() error:\ngot: %+v and %+v\nexpected: the same loot for the same seed", first, second)
+	}
+}
+
+func TestRoll_ConditionsAndNesting(t *testing.T) {
+	src, table := fixtures()
+
+	for seed := int64(0); seed < 20; seed++ {
+		result, err := Roll(context.Background(), src, table, model.LootRollRequest{Adversary: model.Nemesis}, seed)
+		if err != nil {
+			t.Fatalf("Roll() error:\ngot: %v\nexpected: <no error>", err)
+		}
+		for _, item := range result.Items {
+			if item.Kind == model.ArmorKind {
+				t.Errorf("Roll() error:\ngot: %+v\nexpected: no minion-only armor for a nemesis", item)
+			}
+			if item.Name == "Vibroknife" && (item.Table != "Officer Sidearms" || item.Quantity%2 != 0 || item.Weapon == nil) {
+				t.Errorf("Roll() error:\ngot: %+v\nexpected: nested drops rolled twice per draw", item)
+			}
+		}
+	}
+}
+
+func TestRoll_Cycle(t *testing.T) {
+	src, table := fixtures()
+	for _, nested := range src.tables {
+		if nested.ID != table.ID {
+			nested.Entries = append(nested.Entries, model.LootEntry{TableID: &table.ID, Weight: 100, MinQuantity: 1, MaxQuantity: 1})
+		}
+	}
+
+	var err error
+	for seed := int64(0); seed < 20 && err == nil; seed++ {
+		_, err = Roll(context.Background(), src, table, model.LootRollRequest{}, seed)
+	}
+	if _, ok := err.(model.ValidationError); !ok || !strings.Contains(err.Error(), "references itself") {
+		t.Errorf("Roll() error:\ngot: %v\nexpected: a reference cycle error", err)
+	}
+}
+
+func TestRoll_MissingItem(t *testing.T) {
+	src, table := fixtures()
+	src.weapons = nil
+
+	var err error
+	for seed := int64(0); seed < 20 && err == nil; seed++ {
+		_, err = Roll(context.Background(), src, table, model.LootRollRequest{}, seed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "no documents in result") {
+		t.Errorf("Roll() error:\ngot: %v\nexpected: a not found error", err)
+	}
+}
+
+func TestRoll_DrawBudget(t *testing.T) {
+	src, _ := fixtures()
+	weapon := objectID()
+	src.weapons[*weapon] = &model.Weapon{ID: *weapon, Name: "Blaster Pistol"}
+
+	// a chain of distinct tables, every level rolling the next one 20 times 100 over
+	next := &model.LootTable{ID: *objectID(), Name: "Armory", Rolls: model.MaxLootRolls, Entries: []model.LootEntry{
+		{Kind: model.WeaponKind, ItemID: weapon, Weight: 1, MinQuantity: 1, MaxQuantity: 1},
+	}}
+	src.tables[next.ID] = next
+	for level := 0; level < model.MaxLootNestDepth-1; level++ {
+		table := &model.LootTable{ID: *objectID(), Name: "Depot", Rolls: model.MaxLootRolls, Entries: []model.LootEntry{
+			{TableID: &next.ID, Weight: 1, MinQuantity: model.MaxLootQuantity, MaxQuantity: model.MaxLootQuantity},
+		}}
+		src.tables[table.ID] = table
+		next = table
+	}
+
+	_, err := Roll(context.Background(), src, next, model.LootRollRequest{}, 1)
+	if _, ok := err.(model.ValidationError); !ok || !strings.Contains(err.Error(), "draws") {
+		t.Errorf("Roll() error:\ngot: %v\nexpected: a draw budget error", err)
+	}
+}
+
+func TestRoll_Cancelled(t *testing.T) {
+	src, table := fixtures()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Roll(ctx, src, table, model.LootRollRequest{}, 1)
+	if err != context.Canceled {
+		t.Errorf("Roll() error:\ngot: %v\nexpected: %v", err, context.Canceled)
+	}
+}
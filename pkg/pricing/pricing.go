@@ -0,0 +1,160 @@
+// Package pricing computes buy and sell prices for items following the FFG core rulebook market rules.
+// It only depends on the base price of an item so other services can import it without the gear database.
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+)
+
+// Market is where an item is bought or sold
+type Market string
+
+// The markets an item can be traded on
+const (
+	Legal       Market = "legal"
+	BlackMarket Market = "blackMarket"
+	Restricted  Market = "restricted"
+)
+
+// Markets lists every market
+var Markets = []Market{Legal, BlackMarket, Restricted}
+
+// MarketMultipliers is the markup on the base price in each market, the black market charges double
+// and restricted goods traded on the black market cost four times the listed price
+var MarketMultipliers = map[Market]float64{
+	Legal:       1,
+	BlackMarket: 2,
+	Restricted:  4,
+}
+
+// The core rulebook resale and haggling rules
+const (
+	// ResaleFraction is the share of the list price a merchant pays for an item
+	ResaleFraction = 0.25
+	// MaxResaleFraction caps the share of the list price a character can haggle for when selling
+	MaxResaleFraction = 0.5
+	// SuccessAdjustment is the price change for every success beyond the first on the Negotiation check
+	SuccessAdjustment = 0.1
+	// AdvantageAdjustment is the price change for every net advantage, net threat moves the price the other way
+	AdvantageAdjustment = 0.05
+	// MaxDiscount caps the discount a character can haggle for when buying
+	MaxDiscount = 0.5
+	// MaxMarkup caps how much net threat can raise the buy price
+	MaxMarkup = 0.5
+	// MaxPlanetModifier is the largest planet modifier accepted, it keeps the quoted prices within an int64
+	MaxPlanetModifier = 10.0
+)
+
+// ParseMarket returns the market matching the given name, ignoring case, an empty name is the legal market
+func ParseMarket(name string) (Market, error) {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return Legal, nil
+	}
+	for _, market := range Markets {
+		if strings.EqualFold(trimmed, string(market)) {
+			return market, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not a market, expected one of %v", name, Markets)
+}
+
+// Negotiation is the outcome of a Negotiation check, negative advantages are net threat
+type Negotiation struct {
+	Successes  int64 `json:"successes"`
+	Advantages int64 `json:"advantages"`
+}
+
+// Options describes where and how an item is traded
+type Options struct {
+	Market         Market      `json:"market"`
+	PlanetModifier float64     `json:"planetModifier"`
+	Negotiation    Negotiation `json:"negotiation"`
+}
+
+// Quote is the price of an item for the given options
+type Quote struct {
+	BasePrice      int64       `json:"basePrice"`
+	Market         Market      `json:"market"`
+	PlanetModifier float64     `json:"planetModifier"`
+	Negotiation    Negotiation `json:"negotiation"`
+	ListPrice      int64       `json:"listPrice"`
+	BuyPrice       int64       `json:"buyPrice"`
+	SellPrice      int64       `json:"sellPrice"`
+}
+
+// Validate checks the options, a planet modifier of 0 is treated as 1
+func (o *Options) Validate() error {
+	validationErr := model.ValidationError{}
+
+	market, err := ParseMarket(string(o.Market))
+	if err != nil {
+		validationErr.Add("market", err.Error())
+	} else {
+		o.Market = market
+	}
+
+	if o.PlanetModifier == 0 {
+		o.PlanetModifier = 1
+	}
+	if o.PlanetModifier < 0 || o.PlanetModifier > MaxPlanetModifier || math.IsNaN(o.PlanetModifier) {
+		validationErr.Add("planetModifier", fmt.Sprintf("must be a positive number up to %v", MaxPlanetModifier))
+	}
+	if o.Negotiation.Successes < 0 {
+		validationErr.Add("successes", "must not be negative")
+	}
+
+	return validationErr.OrNil()
+}
+
+// Calculate quotes the buy and sell price of an item.
+// Restricted items can only be traded as restricted goods, so their market is raised to Restricted.
+// The list price is the base price with the market markup and planet modifier applied. Every success beyond
+// the first and every net advantage on the Negotiation check lowers the buy price and raises the sell price,
+// net threat does the opposite. The buy price moves by at most half the list price either way, and a merchant pays a
+// quarter of the list price for an item and never more than half.
+func Calculate(basePrice int64, restricted bool, options Options) (Quote, error) {
+	err := options.Validate()
+	if err != nil {
+		return Quote{}, err
+	}
+	if basePrice < 0 {
+		return Quote{}, model.ValidationError{{Field: "price", Message: "must not be negative"}}
+	}
+
+	if restricted {
+		options.Market = Restricted
+	}
+
+	listPrice := float64(basePrice) * MarketMultipliers[options.Market] * options.PlanetModifier
+	if listPrice >= math.MaxInt64 {
+		return Quote{}, model.ValidationError{{Field: "price", Message: "is too large to quote"}}
+	}
+
+	adjustment := float64(options.Negotiation.Advantages) * AdvantageAdjustment
+	if options.Negotiation.Successes > 1 {
+		adjustment += float64(options.Negotiation.Successes-1) * SuccessAdjustment
+	}
+
+	discount := math.Max(math.Min(adjustment, MaxDiscount), -MaxMarkup)
+	resale := math.Min(ResaleFraction*(1+adjustment), MaxResaleFraction)
+
+	return Quote{
+		BasePrice:      basePrice,
+		Market:         options.Market,
+		PlanetModifier: options.PlanetModifier,
+		Negotiation:    options.Negotiation,
+		ListPrice:      round(listPrice),
+		BuyPrice:       round(listPrice * (1 - discount)),
+		SellPrice:      round(math.Max(listPrice*resale, 0)),
+	}, nil
+}
+
+// round rounds a price to the nearest credit
+func round(price float64) int64 {
+	return int64(math.Round(price))
+}
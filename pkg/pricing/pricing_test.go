@@ -0,0 +1,96 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name       string
+		price      int64
+		restricted bool
+		options    Options
+		expected   Quote
+	}{
+		{
+			name:     "legal market",
+			price:    400,
+			options:  Options{},
+			expected: Quote{Market: Legal, ListPrice: 400, BuyPrice: 400, SellPrice: 100},
+		},
+		{
+			name:     "black market on a remote planet",
+			price:    400,
+			options:  Options{Market: "blackmarket", PlanetModifier: 1.5},
+			expected: Quote{Market: BlackMarket, ListPrice: 1200, BuyPrice: 1200, SellPrice: 300},
+		},
+		{
+			name:       "restricted items are traded as restricted goods",
+			price:      1000,
+			restricted: true,
+			options:    Options{Market: Legal},
+			expected:   Quote{Market: Restricted, ListPrice: 4000, BuyPrice: 4000, SellPrice: 1000},
+		},
+		{
+			name:     "haggling",
+			price:    1000,
+			options:  Options{Negotiation: Negotiation{Successes: 3, Advantages: 2}},
+			expected: Quote{Market: Legal, ListPrice: 1000, BuyPrice: 700, SellPrice: 325},
+		},
+		{
+			name:     "haggling is capped",
+			price:    1000,
+			options:  Options{Negotiation: Negotiation{Successes: 12}},
+			expected: Quote{Market: Legal, ListPrice: 1000, BuyPrice: 500, SellPrice: 500},
+		},
+		{
+			name:     "threat",
+			price:    1000,
+			options:  Options{Negotiation: Negotiation{Successes: 1, Advantages: -2}},
+			expected: Quote{Market: Legal, ListPrice: 1000, BuyPrice: 1100, SellPrice: 225},
+		},
+		{
+			name:     "threat is capped",
+			price:    1000,
+			options:  Options{Negotiation: Negotiation{Advantages: -1000}},
+			expected: Quote{Market: Legal, ListPrice: 1000, BuyPrice: 1500, SellPrice: 0},
+		},
+	}
+
+	for _, test := range tests {
+		quote, err := Calculate(test.price, test.restricted, test.options)
+		if err != nil {
+			t.Errorf("Calculate() %v error:\ngot: %v\nexpected: <no error>", test.name, err)
+			continue
+		}
+		if quote.Market != test.expected.Market || quote.ListPrice != test.expected.ListPrice || quote.BuyPrice != test.expected.BuyPrice || quote.SellPrice != test.expected.SellPrice {
+			t.Errorf("Calculate() %v error:\ngot: %+v\nexpected: %+v", test.name, quote, test.expected)
+		}
+	}
+}
+
+func TestCalculate_Invalid(t *testing.T) {
+	_, err := Calculate(100, false, Options{Market: "bazaar", PlanetModifier: -1, Negotiation: Negotiation{Successes: -1}})
+
+	validationErr, ok := err.(model.ValidationError)
+	if !ok || len(validationErr) != 3 {
+		t.Errorf("Calculate() error:\ngot: %v\nexpected: market, planetModifier and successes errors", err)
+	}
+}
+
+func TestCalculate_TooLarge(t *testing.T) {
+	_, err := Calculate(100, false, Options{PlanetModifier: MaxPlanetModifier + 1})
+	validationErr, ok := err.(model.ValidationError)
+	if !ok || len(validationErr) != 1 || validationErr[0].Field != "planetModifier" {
+		t.Errorf("Calculate() error:\ngot: %v\nexpected: a planetModifier error", err)
+	}
+
+	_, err = Calculate(math.MaxInt64, true, Options{PlanetModifier: MaxPlanetModifier})
+	validationErr, ok = err.(model.ValidationError)
+	if !ok || len(validationErr) != 1 || validationErr[0].Field != "price" {
+		t.Errorf("Calculate() error:\ngot: %v\nexpected: a price error", err)
+	}
+}
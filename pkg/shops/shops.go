@@ -0,0 +1,106 @@
+// Package shops generates the stock of a store from the weapon and armor catalog.
+// Generation only depends on the request, the catalog and the seed so the same inputs always produce the same shop.
+package shops
+
+import (
+	"math/rand"
+	"sort"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"github.com/geeksheik9/gear-CRUD/pkg/pricing"
+)
+
+// GeneralStoreMaxRarity is the highest adjusted rarity a general store stocks
+const GeneralStoreMaxRarity = 5
+
+// candidate is a catalog item a shop could stock
+type candidate struct {
+	item   model.ShopItem
+	price  int64
+	weight int64
+}
+
+// Generate samples the shop's stock from the catalog, weighting every item by how common it is at the location.
+// Armories and general stores only stock legal items, general stores stick to common ones, and black markets
+// carry restricted goods at black market prices.
+func Generate(request model.ShopRequest, weapons []model.Weapon, armors []model.Armor, seed int64) (model.Shop, error) {
+	shop := model.Shop{
+		Name:             request.Name,
+		Location:         request.Location,
+		LocationModifier: request.LocationModifier,
+		Specialty:        request.Specialty,
+		PlanetModifier:   request.PlanetModifier,
+		Size:             request.Size,
+		Seed:             seed,
+		Items:            []model.ShopItem{},
+	}
+	if shop.PlanetModifier == 0 {
+		shop.PlanetModifier = 1
+	}
+
+	availability := model.AvailabilityRequest{LocationModifier: request.LocationModifier, BlackMarket: request.Specialty == model.BlackMarketStore}
+	candidates := []candidate{}
+	for _, weapon := range weapons {
+		item := model.ShopItem{Kind: model.WeaponKind, ItemID: weapon.ID, Name: weapon.Name, Rarity: weapon.Rarity, Restricted: weapon.Restricted}
+		candidates = appendCandidate(candidates, item, weapon.Price, availability, request.Specialty)
+	}
+	for _, armor := range armors {
+		item := model.ShopItem{Kind: model.ArmorKind, ItemID: armor.ID, Name: armor.ArmorType, Rarity: armor.Rarity, Restricted: armor.Restricted}
+		candidates = appendCandidate(candidates, item, armor.Price, availability, request.Specialty)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].item.ItemID.Hex() < candidates[j].item.ItemID.Hex() })
+
+	market := pricing.Legal
+	if request.Specialty == model.BlackMarketStore {
+		market = pricing.BlackMarket
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	for int64(len(shop.Items)) < request.Size && len(candidates) > 0 {
+		picked := pick(random, candidates)
+		chosen := candidates[picked]
+		candidates = append(candidates[:picked], candidates[picked+1:]...)
+
+		quote, err := pricing.Calculate(chosen.price, chosen.item.Restricted, pricing.Options{Market: market, PlanetModifier: shop.PlanetModifier})
+		if err != nil {
+			return model.Shop{}, err
+		}
+		chosen.item.Price = quote.BuyPrice
+		chosen.item.Quantity = 1 + random.Int63n(chosen.weight/4+1)
+
+		shop.Items = append(shop.Items, chosen.item)
+	}
+
+	return shop, nil
+}
+
+// appendCandidate adds the item when the shop would stock it, common items get a higher weight
+func appendCandidate(candidates []candidate, item model.ShopItem, price int64, availability model.AvailabilityRequest, specialty model.ShopSpecialty) []candidate {
+	if item.Restricted && specialty != model.BlackMarketStore {
+		return candidates
+	}
+
+	item.AdjustedRarity = availability.Check(item.Rarity, item.Restricted).AdjustedRarity
+	if specialty == model.GeneralStore && item.AdjustedRarity > GeneralStoreMaxRarity {
+		return candidates
+	}
+
+	return append(candidates, candidate{item: item, price: price, weight: model.MaxRarity + 1 - item.AdjustedRarity})
+}
+
+// pick returns the index of a randomly chosen candidate, weighted by the candidates' weights
+func pick(random *rand.Rand, candidates []candidate) int {
+	var total int64
+	for _, c := range candidates {
+		total += c.weight
+	}
+
+	roll := random.Int63n(total)
+	for i, c := range candidates {
+		if roll < c.weight {
+			return i
+		}
+		roll -= c.weight
+	}
+	return len(candidates) - 1
+}
@@ -0,0 +1,71 @@
+package shops
+
+import (
+	"reflect"
+	"testing"
+
+	model "github.com/geeksheik9/gear-CRUD/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func catalog() ([]model.Weapon, []model.Armor) {
+	weapons := []model.Weapon{
+		{ID: primitive.NewObjectID(), Name: "Blaster Pistol", Price: 400, Rarity: 4},
+		{ID: primitive.NewObjectID(), Name: "Vibroknife", Price: 250, Rarity: 3},
+		{ID: primitive.NewObjectID(), Name: "Disruptor Rifle", Price: 5000, Rarity: 6, Restricted: true},
+		{ID: primitive.NewObjectID(), Name: "Lightsaber", Price: 10000, Rarity: 10, Restricted: true},
+	}
+	armors := []model.Armor{
+		{ID: primitive.NewObjectID(), ArmorType: "Padded Armor", Price: 500, Rarity: 1},
+		{ID: primitive.NewObjectID(), ArmorType: "Armored Clothing", Price: 1000, Rarity: 6},
+	}
+	return weapons, armors
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	weapons, armors := catalog()
+	request := model.ShopRequest{Specialty: model.Armory, Size: 3, LocationModifier: 1}
+
+	first, err := Generate(request, weapons, armors, 99)
+	if err != nil {
+		t.Fatalf("Generate() error:\ngot: %v\nexpected: <no error>", err)
+	}
+
+	// the catalog order must not matter
+	weapons[0], weapons[1] = weapons[1], weapons[0]
+	second, _ := Generate(request, weapons, armors, 99)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Generate() error:\ngot: %+v and %+v\nexpected: the same stock for the same seed", first, second)
+	}
+	if len(first.Items) != 3 || first.Seed != 99 || first.PlanetModifier != 1 {
+		t.Errorf("Generate() error:\ngot: %+v\nexpected: 3 items", first)
+	}
+	for _, item := range first.Items {
+		if item.Restricted || item.Quantity < 1 || item.AdjustedRarity != item.Rarity+1 {
+			t.Errorf("Generate() error:\ngot: %+v\nexpected: a legal item adjusted for the location", item)
+		}
+	}
+}
+
+func TestGenerate_Specialties(t *testing.T) {
+	weapons, armors := catalog()
+
+	general, _ := Generate(model.ShopRequest{Specialty: model.GeneralStore, Size: 10}, weapons, armors, 1)
+	if len(general.Items) != 3 {
+		t.Errorf("Generate() error:\ngot: %+v\nexpected: only the 3 common legal items", general.Items)
+	}
+
+	black, _ := Generate(model.ShopRequest{Specialty: model.BlackMarketStore, Size: 10}, weapons, armors, 1)
+	if len(black.Items) != 6 {
+		t.Errorf("Generate() error:\ngot: %+v\nexpected: every item", black.Items)
+	}
+	for _, item := range black.Items {
+		if item.Name == "Blaster Pistol" && item.Price != 800 {
+			t.Errorf("Generate() error:\ngot: %+v\nexpected: black market price of 800", item)
+		}
+		if item.Name == "Disruptor Rifle" && (item.Price != 20000 || item.AdjustedRarity != 5) {
+			t.Errorf("Generate() error:\ngot: %+v\nexpected: restricted price of 20000 and rarity 5", item)
+		}
+	}
+}